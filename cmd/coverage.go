@@ -25,6 +25,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/cmdutil"
@@ -32,10 +34,16 @@ import (
 	"github.com/k1LoW/tbls/coverage"
 	"github.com/labstack/gommon/color"
 	"github.com/mattn/go-runewidth"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
-var cformat string
+var (
+	cformat           string
+	coverageFailUnder float64
+	coverageBadge     string
+	coverageHistory   string
+)
 
 // coverageCmd represents the coverage command.
 var coverageCmd = &cobra.Command{
@@ -76,8 +84,12 @@ var coverageCmd = &cobra.Command{
 		cover := coverage.Measure(s)
 
 		maxWidth := runewidth.StringWidth("All tables")
-		for _, t := range cover.Tables {
-			l := runewidth.StringWidth(t.Name)
+		names := lo.Map(cover.Tables, func(t *coverage.TableCoverage, _ int) string { return t.Name })
+		for _, g := range append(cover.Labels, cover.Viewpoints...) {
+			names = append(names, g.Name)
+		}
+		for _, n := range names {
+			l := runewidth.StringWidth(n)
 			if l+1 > maxWidth {
 				maxWidth = l + 1
 			}
@@ -98,11 +110,83 @@ var coverageCmd = &cobra.Command{
 			for _, t := range cover.Tables {
 				fmt.Printf(" %s %g%%\n", fmt.Sprintf(fmtName, t.Name), t.Coverage)
 			}
+			for _, section := range []lo.Tuple2[string, []*coverage.GroupCoverage]{
+				lo.T2("Label", cover.Labels),
+				lo.T2("Viewpoint", cover.Viewpoints),
+			} {
+				title, groups := section.Unpack()
+				if len(groups) == 0 {
+					continue
+				}
+				fmt.Println()
+				fmt.Printf("%s  %s\n", color.White(fmt.Sprintf(fmtName, title), color.B), color.White("Coverage", color.B))
+				for _, g := range groups {
+					fmt.Printf(" %s %g%%\n", fmt.Sprintf(fmtName, g.Name), g.Coverage)
+				}
+			}
+		}
+
+		if coverageBadge != "" {
+			if err := writeCoverageBadge(cover, coverageBadge); err != nil {
+				return err
+			}
+		}
+
+		if coverageHistory != "" {
+			if err := writeCoverageHistory(cover, coverageHistory, c.DocPath); err != nil {
+				return err
+			}
+		}
+
+		violations := cover.Check(coverageFailUnder, c.Coverage)
+		if len(violations) > 0 {
+			for _, v := range violations {
+				_, _ = fmt.Fprintln(os.Stderr, v)
+			}
+			os.Exit(1)
 		}
 		return nil
 	},
 }
 
+func writeCoverageBadge(cover *coverage.Coverage, path string) (e error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) // #nosec
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && e == nil {
+			e = errors.WithStack(err)
+		}
+	}()
+	return cover.OutputBadge(f)
+}
+
+// writeCoverageHistory append the coverage to the history file and write the trend chart to the document directory.
+func writeCoverageHistory(cover *coverage.Coverage, path, docPath string) (e error) {
+	h, err := coverage.LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	h = h.Append(cover, time.Now().UTC())
+	if err := h.Write(path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(docPath, 0755); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+	f, err := os.OpenFile(filepath.Join(docPath, coverage.TrendFileName), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) // #nosec
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && e == nil {
+			e = errors.WithStack(err)
+		}
+	}()
+	return h.OutputTrend(f)
+}
+
 func loadCoverageArgs(args []string) ([]config.Option, error) {
 	options := []config.Option{}
 	if len(args) > 1 {
@@ -122,5 +206,8 @@ func init() {
 	coverageCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
 	coverageCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
 	coverageCmd.Flags().StringVarP(&cformat, "format", "t", "", "output format")
+	coverageCmd.Flags().Float64VarP(&coverageFailUnder, "fail-under", "", 0, "exit with status 1 if the coverage of all tables is under the value (default: coverage.failUnder in the config)")
+	coverageCmd.Flags().StringVarP(&coverageBadge, "badge", "", "", "output the SVG badge of the coverage to the file")
+	coverageCmd.Flags().StringVarP(&coverageHistory, "history", "", "", fmt.Sprintf("append the coverage to the history file and output the trend chart to %s in docPath", coverage.TrendFileName))
 	coverageCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
 }
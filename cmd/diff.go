@@ -21,18 +21,37 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/cmdutil"
 	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/datasource"
+	"github.com/k1LoW/tbls/gitutil"
+	"github.com/k1LoW/tbls/output/diff"
 	"github.com/k1LoW/tbls/output/md"
 	"github.com/k1LoW/tbls/schema"
 	"github.com/spf13/cobra"
 )
 
+var (
+	diffFormat  string
+	diffEmitSQL string
+	diffBaseRef string
+)
+
+// Exit status of `tbls diff`.
+const (
+	diffExitStatusDocsChanged   = 1 // Markdown documents differ
+	diffExitStatusSchemaChanged = 2 // additive or compatible changes that fail by diff.policy
+	diffExitStatusBreaking      = 3 // breaking changes that fail by diff.policy
+)
+
 // diffCmd represents the diff command.
 var diffCmd = &cobra.Command{
 	Use:   "diff [DSN] [DSN_OR_DOC_PATH]",
@@ -61,11 +80,70 @@ var diffCmd = &cobra.Command{
 			s       *schema.Schema
 			s2      *schema.Schema
 			docPath string
-			diff    string
+			text    string
+			// dsnFirst is whether the DSN is given before the document path
+			dsnFirst bool
 		)
 
+		var (
+			o *diff.Diff
+			m *diff.SQL
+		)
+		if diffFormat != "" {
+			o, err = diff.New(diffFormat)
+			if err != nil {
+				return err
+			}
+		}
+		if diffEmitSQL != "" {
+			m, err = diff.NewSQL(c, diffEmitSQL)
+			if err != nil {
+				return err
+			}
+		}
+
 		options := loadDiffOpts()
 
+		if diffBaseRef != "" {
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments with --base-ref: %s", strings.Join(args, " "))
+			}
+			if o == nil && m == nil {
+				if o, err = diff.New("text"); err != nil {
+					return err
+				}
+			}
+			docPath = ""
+			if len(args) == 1 {
+				if _, err := os.Lstat(args[0]); err == nil {
+					// b:path
+					docPath = args[0]
+				} else {
+					// b:dsn
+					options = append(options, config.DSNURL(args[0]))
+				}
+			}
+			if err := c.Load(configPath, options...); err != nil {
+				return err
+			}
+			from, err := loadSchemaFromGitRevision(diffBaseRef, c.SchemaFilePath())
+			if err != nil {
+				return err
+			}
+			if docPath != "" {
+				s, err = loadSchemaFromDocPath(docPath)
+			} else {
+				s, err = datasource.Analyze(c.DSN)
+				if err == nil {
+					err = c.ModifySchema(s)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return outputSchemaChanges(from, s, o, m, c)
+		}
+
 		switch len(args) {
 		case 2:
 			if _, err := os.Lstat(args[1]); err == nil {
@@ -75,6 +153,7 @@ var diffCmd = &cobra.Command{
 				}
 				c2 = nil
 				docPath = args[1]
+				dsnFirst = true
 			} else if !strings.Contains(args[1], "://") {
 				// a:dsn and b:path
 				if err := c.Load(configPath, append(options, config.DSNURL(args[0]))...); err != nil {
@@ -82,6 +161,7 @@ var diffCmd = &cobra.Command{
 				}
 				c2 = nil
 				docPath = args[1]
+				dsnFirst = true
 			} else {
 				// a:dsn and b:dsn
 				if err := c.Load(configPath, append(options, config.DSNURL(args[0]))...); err != nil {
@@ -134,26 +214,110 @@ var diffCmd = &cobra.Command{
 			}
 		}
 
+		if o != nil || m != nil {
+			var from, to *schema.Schema
+			switch {
+			case docPath != "":
+				var doc *schema.Schema
+				doc, err = loadSchemaFromDocPath(docPath)
+				from, to = orderSchemas(s, doc, dsnFirst)
+			case s2 != nil:
+				from = s
+				to = s2
+			default:
+				from, err = loadSchemaFromDocPath(c.DocPath)
+				to = s
+			}
+			if err != nil {
+				return err
+			}
+			return outputSchemaChanges(from, to, o, m, c)
+		}
+
 		switch {
 		case docPath != "":
-			diff, err = md.DiffSchemaAndDocs(docPath, s, c)
+			text, err = md.DiffSchemaAndDocs(docPath, s, c)
 		case s2 != nil:
-			diff, err = md.DiffSchemas(s, s2, c, c2)
+			text, err = md.DiffSchemas(s, s2, c, c2)
 		default:
-			diff, err = md.DiffSchemaAndDocs(c.DocPath, s, c)
+			text, err = md.DiffSchemaAndDocs(c.DocPath, s, c)
 		}
 		if err != nil {
 			return err
 		}
-		fmt.Print(diff)
-		if diff != "" {
-			os.Exit(1)
+		fmt.Print(text)
+		if text != "" {
+			os.Exit(diffExitStatusDocsChanged)
 		}
 
 		return nil
 	},
 }
 
+// loadSchemaFromDocPath load schema.json generated by `tbls doc` in docPath.
+func loadSchemaFromDocPath(docPath string) (*schema.Schema, error) {
+	return datasource.AnalyzeJSONStringOrFile(filepath.Join(docPath, config.SchemaFileName))
+}
+
+// orderSchemas return the schemas of the database and the document in the order to compare.
+// Changes are computed from the first argument to the second one, so the database is compared first only when the DSN is given before the document path.
+func orderSchemas(db, doc *schema.Schema, dsnFirst bool) (*schema.Schema, *schema.Schema) {
+	if dsnFirst {
+		return db, doc
+	}
+	return doc, db
+}
+
+// loadSchemaFromGitRevision load schema.json committed at the git revision.
+func loadSchemaFromGitRevision(rev, schemaFilePath string) (*schema.Schema, error) {
+	b, err := gitutil.Show(rev, schemaFilePath)
+	if err != nil {
+		return nil, err
+	}
+	s := &schema.Schema{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if err := dec.Decode(s); err != nil {
+		return nil, errors.WithStack(fmt.Errorf("failed to decode %s at %s: %w", schemaFilePath, rev, err))
+	}
+	if err := s.Repair(); err != nil {
+		return nil, errors.WithStack(fmt.Errorf("failed to repair %s at %s: %w", schemaFilePath, rev, err))
+	}
+	return s, nil
+}
+
+// outputSchemaChanges output the changes from schema `from` to schema `to`, and exit with the status by the policy.
+func outputSchemaChanges(from, to *schema.Schema, o *diff.Diff, m *diff.SQL, c *config.Config) error {
+	changes := schema.Diff(from, to, c.Renames...)
+	if m != nil {
+		if err := m.OutputMigration(os.Stdout, from, to, changes); err != nil {
+			return err
+		}
+	} else {
+		if err := o.OutputChanges(os.Stdout, changes); err != nil {
+			return err
+		}
+	}
+	if status := diffExitStatus(changes, c.Diff.Policy); status != 0 {
+		os.Exit(status)
+	}
+	return nil
+}
+
+// diffExitStatus return the exit status for the changes that fail by the policy.
+func diffExitStatus(changes schema.Changes, policy config.DiffPolicy) int {
+	status := 0
+	for _, ch := range changes {
+		if !policy.IsFailure(ch.Class) {
+			continue
+		}
+		if ch.Class == schema.ChangeClassBreaking {
+			return diffExitStatusBreaking
+		}
+		status = diffExitStatusSchemaChanged
+	}
+	return status
+}
+
 func loadDiffOpts() []config.Option {
 	options := []config.Option{}
 	if adjust {
@@ -173,6 +337,10 @@ func init() {
 	diffCmd.Flags().StringVarP(&erFormat, "er-format", "t", "", fmt.Sprintf("ER diagrams output format (png, svg, jpg, ...). default: %s", config.DefaultERFormat))
 	diffCmd.Flags().BoolVarP(&adjust, "adjust-table", "j", false, "adjust column width of table")
 	diffCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "", "", fmt.Sprintf("compare schema objects and output the changes in the format (%s). default: diff of Markdown documents", strings.Join(diff.SupportFormats, ", ")))
+	diffCmd.Flags().StringVarP(&diffEmitSQL, "emit-sql", "", "", fmt.Sprintf("compare schema objects and output SQL statements to turn the first schema into the second in the dialect (%s)", strings.Join(diff.SupportDialects, ", ")))
+	diffCmd.Flags().StringVarP(&diffBaseRef, "base-ref", "", "", "compare schema objects with schema.json in docPath committed at the git revision (e.g. origin/main)")
+	diffCmd.MarkFlagsMutuallyExclusive("format", "emit-sql")
 	if err := diffCmd.MarkZshCompPositionalArgumentFile(2); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
 		os.Exit(1)
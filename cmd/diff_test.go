@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+func TestOrderSchemas(t *testing.T) {
+	db := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{Name: "users", Type: "BASE TABLE", Columns: []*schema.Column{{Name: "id", Type: "bigint"}}},
+		},
+	}
+	doc := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{Name: "users", Type: "BASE TABLE", Columns: []*schema.Column{{Name: "id", Type: "bigint"}, {Name: "email", Type: "text", Nullable: true}}},
+		},
+	}
+	tests := []struct {
+		name     string
+		dsnFirst bool
+		want     schema.ChangeAction
+	}{
+		// tbls diff DSN path
+		{"dsn and path", true, schema.ChangeActionAdded},
+		// tbls diff path
+		{"path and dsn in config", false, schema.ChangeActionRemoved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := orderSchemas(db, doc, tt.dsnFirst)
+			changes := schema.Diff(from, to)
+			if len(changes) != 1 {
+				t.Fatalf("got %v\nwant %v", len(changes), 1)
+			}
+			if got := changes[0].Action; got != tt.want {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -31,22 +31,29 @@ import (
 	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/datasource"
 	"github.com/k1LoW/tbls/output/gviz"
+	"github.com/k1LoW/tbls/output/html"
 	"github.com/k1LoW/tbls/output/json"
 	"github.com/k1LoW/tbls/output/md"
+	"github.com/k1LoW/tbls/output/viewer"
 	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
 
 var (
 	withoutER bool
 	rmDist    bool
+	docFormat string
 )
 
+// supportDocFormats is the formats of the document.
+var supportDocFormats = []string{"md", "html"}
+
 // docCmd represents the doc command.
 var docCmd = &cobra.Command{
 	Use:   "doc [DSN] [DOC_PATH]",
 	Short: "document a database",
-	Long:  `'tbls doc' analyzes a database and generate document in GitHub Friendly Markdown format.`,
+	Long:  `'tbls doc' analyzes a database and generate document in GitHub Friendly Markdown format or static HTML format.`,
 	RunE: func(_ *cobra.Command, args []string) error {
 		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
 			if err != nil {
@@ -55,6 +62,10 @@ var docCmd = &cobra.Command{
 			return nil
 		}
 
+		if !lo.Contains(supportDocFormats, docFormat) {
+			return fmt.Errorf("unsupported document format: %s", docFormat)
+		}
+
 		c, err := config.New()
 		if err != nil {
 			return err
@@ -92,14 +103,22 @@ var docCmd = &cobra.Command{
 			}
 		}
 
-		if c.NeedToGenerateERImages() {
-			if err := gviz.Output(s, c, force); err != nil {
+		switch docFormat {
+		case "html":
+			// ER diagrams are inlined in the HTML pages
+			if err := html.Output(s, c, force); err != nil {
 				return err
 			}
-		}
+		default:
+			if c.NeedToGenerateERImages() {
+				if err := gviz.Output(s, c, force); err != nil {
+					return err
+				}
+			}
 
-		if err := md.Output(s, c, force); err != nil {
-			return err
+			if err := md.Output(s, c, force); err != nil {
+				return err
+			}
 		}
 
 		// output schema.json
@@ -109,6 +128,13 @@ var docCmd = &cobra.Command{
 			}
 		}
 
+		// output schema-viewer.html
+		if c.ER.Viewer {
+			if err := withERViewerFile(s, c); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	},
 }
@@ -129,6 +155,22 @@ func withSchemaFile(s *schema.Schema, c *config.Config) (e error) {
 	return nil
 }
 
+func withERViewerFile(s *schema.Schema, c *config.Config) (e error) {
+	f, err := os.Create(c.ERViewerFilePath())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	fmt.Printf("%s\n", c.ERViewerFilePath())
+	v := viewer.New(c)
+	if err := v.OutputSchema(f, s); err != nil {
+		return err
+	}
+	return nil
+}
+
 func loadDocArgs(args []string) ([]config.Option, error) {
 	options := []config.Option{}
 	if len(args) > 2 {
@@ -172,6 +214,7 @@ func init() {
 	docCmd.Flags().BoolVarP(&adjust, "adjust-table", "j", false, "adjust column width of table")
 	docCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
 	docCmd.Flags().StringVarP(&baseURL, "base-url", "b", "", "base url for links")
+	docCmd.Flags().StringVarP(&docFormat, "format", "", "md", fmt.Sprintf("document format (%s)", strings.Join(supportDocFormats, ", ")))
 	docCmd.Flags().BoolVarP(&rmDist, "rm-dist", "", false, "remove files in docPath before generating documents")
 	docCmd.Flags().StringSliceVarP(&tables, "table", "", []string{}, "target table (tables to include)")
 	docCmd.Flags().StringSliceVarP(&includes, "include", "", []string{}, "tables to include")
@@ -0,0 +1,124 @@
+/*
+Copyright © 2026 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/cmdutil"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/gitutil"
+	"github.com/k1LoW/tbls/history"
+	"github.com/k1LoW/tbls/output/md"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/spf13/cobra"
+)
+
+// historyJSONFileName is the file name of the schema history data.
+const historyJSONFileName = "history.json"
+
+// historyCmd represents the history command.
+var historyCmd = &cobra.Command{
+	Use:   "history [DOC_PATH]",
+	Short: "generate the change history of schema from git",
+	Long:  `'tbls history' walks the git history of schema.json in the document path and generates the change history of schema.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if allow, err := cmdutil.IsAllowedToExecute(when); !allow || err != nil {
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+
+		c, err := config.New()
+		if err != nil {
+			return err
+		}
+
+		options := []config.Option{}
+		if len(args) == 1 {
+			options = append(options, config.DocPath(args[0]))
+		}
+		if err := c.Load(configPath, options...); err != nil {
+			return err
+		}
+
+		commits, err := gitutil.Log(c.SchemaFilePath())
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			return fmt.Errorf("no commits of %s", c.SchemaFilePath())
+		}
+		h, err := history.Build(commits, func(cm *gitutil.Commit) (*schema.Schema, error) {
+			return loadSchemaFromGitRevision(cm.Hash, c.SchemaFilePath())
+		}, c.Renames...)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(c.DocPath, 0755); err != nil { // #nosec
+			return errors.WithStack(err)
+		}
+		if err := writeHistoryFile(filepath.Join(c.DocPath, md.HistoryFileName), func(f *os.File) error {
+			return md.New(c).OutputHistory(f, h)
+		}); err != nil {
+			return err
+		}
+		if err := writeHistoryFile(filepath.Join(c.DocPath, historyJSONFileName), func(f *os.File) error {
+			encoder := json.NewEncoder(f)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(h)
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func writeHistoryFile(p string, write func(f *os.File) error) (e error) {
+	f, err := os.Create(filepath.Clean(p))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			e = errors.WithStack(err)
+		}
+	}()
+	if err := write(f); err != nil {
+		return errors.WithStack(err)
+	}
+	fmt.Printf("%s\n", p)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
+	historyCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+}
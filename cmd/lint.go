@@ -21,18 +21,29 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
-	"reflect"
+	"path/filepath"
+	"strings"
 
 	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/cmdutil"
 	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/datasource"
-	"github.com/labstack/gommon/color"
+	tbls_config "github.com/k1LoW/tbls/output/config"
+	"github.com/k1LoW/tbls/output/lint"
+	"github.com/k1LoW/tbls/schema"
 	"github.com/spf13/cobra"
 )
 
+var (
+	lintFormat         string
+	lintUpdateBaseline bool
+	lintFix            bool
+	lintFixSQL         string
+)
+
 // lintCmd represents the lint command.
 var lintCmd = &cobra.Command{
 	Use:   "lint [DSN] [DOC_PATH]",
@@ -69,23 +80,41 @@ var lintCmd = &cobra.Command{
 			return err
 		}
 
-		l := reflect.Indirect(reflect.ValueOf(c.Lint))
-		t := l.Type()
+		o, err := lint.New(c, lintFormat)
+		if err != nil {
+			return err
+		}
+
+		ruleWarns, err := c.Lint.Check(s, s.NormalizeTableNames(c.LintExclude))
+		if err != nil {
+			return err
+		}
 
-		ruleWarns := []config.RuleWarn{}
-		for i := 0; i < t.NumField(); i++ {
-			r := l.Field(i)
-			v, ok := r.Interface().(config.Rule)
-			if !ok {
-				return fmt.Errorf("invalid rule: %v", r.Interface())
-			}
-			ruleWarns = append(ruleWarns, v.Check(s, s.NormalizeTableNames(c.LintExclude))...)
+		if lintFix {
+			return fixConfigComments(c, s, ruleWarns)
 		}
-		if len(ruleWarns) > 0 {
-			for _, warn := range ruleWarns {
-				fmt.Printf("%s%s\n", color.Cyan(warn.Target), color.White(fmt.Sprintf(": %s", warn.Message), color.B))
+
+		if lintFixSQL != "" {
+			return fixSQLComments(s, ruleWarns)
+		}
+
+		if lintUpdateBaseline {
+			if err := config.NewLintBaseline(ruleWarns).Write(c.LintBaselinePath()); err != nil {
+				return err
 			}
-			fmt.Println(color.White(fmt.Sprintf("\n%d detected", len(ruleWarns)), color.B))
+			_, _ = fmt.Fprintf(os.Stderr, "%d warnings are recorded to %s\n", len(ruleWarns), c.LintBaselinePath())
+			return nil
+		}
+
+		b, err := config.LoadLintBaseline(c.LintBaselinePath())
+		if err != nil {
+			return err
+		}
+		ruleWarns = b.Subtract(ruleWarns)
+		if err := o.OutputWarns(os.Stdout, ruleWarns); err != nil {
+			return err
+		}
+		if config.HasError(ruleWarns) {
 			os.Exit(1)
 		}
 
@@ -93,6 +122,53 @@ var lintCmd = &cobra.Command{
 	},
 }
 
+// fixConfigComments add `comments:` with TODO placeholders to the config file.
+func fixConfigComments(c *config.Config, s *schema.Schema, warns []config.RuleWarn) error {
+	path := c.Path
+	if path == "" {
+		path = config.DefaultConfigFilePaths[0]
+	}
+	in, err := os.ReadFile(filepath.Clean(path))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	buf := &bytes.Buffer{}
+	fixed, err := tbls_config.New(c).OutputFix(buf, in, s, warns)
+	if err != nil {
+		return err
+	}
+	if fixed == 0 {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Clean(path), buf.Bytes(), 0644); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "%d comments are added to %s\n", fixed, path)
+	return nil
+}
+
+// fixSQLComments output COMMENT statements with TODO placeholders.
+func fixSQLComments(s *schema.Schema, warns []config.RuleWarn) (e error) {
+	f, err := lint.NewFixSQL(lintFixSQL, tbls_config.TODOComment)
+	if err != nil {
+		return err
+	}
+	wr := os.Stdout
+	if outPath != "" {
+		file, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) // #nosec
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		defer func() {
+			if err := file.Close(); err != nil && e == nil {
+				e = errors.WithStack(err)
+			}
+		}()
+		wr = file
+	}
+	return f.OutputFix(wr, s, warns)
+}
+
 func loadLintArgs(args []string) ([]config.Option, error) {
 	options := []config.Option{}
 	if len(args) > 2 {
@@ -116,6 +192,12 @@ func init() {
 	lintCmd.Flags().StringVarP(&dsn, "dsn", "", "", "data source name")
 	lintCmd.Flags().StringVarP(&configPath, "config", "c", "", "config file path")
 	lintCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
+	lintCmd.Flags().BoolVarP(&lintUpdateBaseline, "update-baseline", "", false, "record the current warnings to the baseline file")
+	lintCmd.Flags().BoolVarP(&lintFix, "fix", "", false, "add comments with TODO placeholders for the tables and columns without comments to the config file")
+	lintCmd.Flags().StringVarP(&lintFixSQL, "fix-sql", "", "", fmt.Sprintf("output COMMENT statements with TODO placeholders for the tables and columns without comments in the dialect (%s)", strings.Join(lint.SupportFixDialects, ", ")))
+	lintCmd.Flags().StringVarP(&outPath, "out", "o", "", "output file path of --fix-sql")
+	lintCmd.MarkFlagsMutuallyExclusive("fix", "fix-sql", "update-baseline")
+	lintCmd.Flags().StringVarP(&lintFormat, "format", "", "text", fmt.Sprintf("output format of lint results (%s)", strings.Join(lint.SupportFormats, ", ")))
 	err := lintCmd.MarkZshCompPositionalArgumentFile(2)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
@@ -30,21 +30,31 @@ import (
 	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/output"
 	tbls_config "github.com/k1LoW/tbls/output/config"
+	"github.com/k1LoW/tbls/output/d2"
+	"github.com/k1LoW/tbls/output/dbml"
 	"github.com/k1LoW/tbls/output/dot"
+	"github.com/k1LoW/tbls/output/drawio"
+	"github.com/k1LoW/tbls/output/graphml"
 	"github.com/k1LoW/tbls/output/gviz"
 	"github.com/k1LoW/tbls/output/json"
+	"github.com/k1LoW/tbls/output/jsonschema"
 	"github.com/k1LoW/tbls/output/md"
 	"github.com/k1LoW/tbls/output/mermaid"
 	"github.com/k1LoW/tbls/output/plantuml"
+	"github.com/k1LoW/tbls/output/prisma"
+	"github.com/k1LoW/tbls/output/sql"
+	"github.com/k1LoW/tbls/output/sqlite"
 	"github.com/k1LoW/tbls/output/xlsx"
 	"github.com/k1LoW/tbls/output/yaml"
 	"github.com/spf13/cobra"
 )
 
 var (
-	format   string
-	outPath  string
-	distance int
+	format      string
+	outPath     string
+	distance    int
+	columnNodes bool
+	dialect     string
 )
 
 // outCmd represents the doc command.
@@ -74,6 +84,11 @@ var outCmd = &cobra.Command{
 			return err
 		}
 
+		if format == "sqlite" && outPath == "" {
+			// the binary database file must not be written to the terminal
+			return errors.New("sqlite format requires the output file path (--out)")
+		}
+
 		s, err := getSchemaFromJSONorDSN(c)
 		if err != nil {
 			return err
@@ -84,6 +99,8 @@ var outCmd = &cobra.Command{
 		switch format {
 		case "json":
 			o = json.New(false)
+		case "jsonschema":
+			o = jsonschema.New(c)
 		case "yaml":
 			o = new(yaml.YAML)
 		case "dot":
@@ -97,6 +114,23 @@ var outCmd = &cobra.Command{
 			o = plantuml.New(c)
 		case "mermaid":
 			o = mermaid.New(c)
+		case "dbml":
+			o = dbml.New(c)
+		case "d2":
+			o = d2.New(c)
+		case "drawio":
+			o = drawio.New(c)
+		case "graphml":
+			o = graphml.New(c, columnNodes)
+		case "prisma":
+			o = prisma.New(c)
+		case "sql":
+			o, err = sql.New(c, dialect)
+			if err != nil {
+				return err
+			}
+		case "sqlite":
+			o = sqlite.New(c)
 		case "png", "svg", "jpg":
 			c.ER.Format = format
 			o = gviz.New(c)
@@ -167,5 +201,7 @@ func init() {
 	outCmd.Flags().StringSliceVarP(&excludes, "exclude", "", []string{}, "tables to exclude")
 	outCmd.Flags().StringSliceVarP(&labels, "label", "", []string{}, "table labels to be included")
 	outCmd.Flags().IntVarP(&distance, "distance", "", 0, "distance between related tables to be displayed")
+	outCmd.Flags().BoolVarP(&columnNodes, "column-nodes", "", false, "output columns as nodes (graphml only)")
+	outCmd.Flags().StringVarP(&dialect, "dialect", "", "", "SQL dialect of DDL (postgres|mysql|sqlite|sqlserver) (sql only). default is the dialect of the database")
 	outCmd.Flags().StringVarP(&when, "when", "", "", "command execute condition")
 }
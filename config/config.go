@@ -29,6 +29,9 @@ var SupportERFormat = []string{"png", "jpg", "svg", "mermaid"}
 
 const SchemaFileName = "schema.json"
 
+// ERViewerFileName is the file name of the interactive ER viewer generated next to schema.json.
+const ERViewerFileName = "schema-viewer.html"
+
 // DefaultERDistance is the default distance between tables that display relations in the ER.
 var DefaultERDistance = 1
 
@@ -47,6 +50,10 @@ type Config struct {
 	Distance               int                    `yaml:"distance,omitempty"`
 	Lint                   Lint                   `yaml:"lint,omitempty"`
 	LintExclude            []string               `yaml:"lintExclude,omitempty"`
+	LintBaseline           string                 `yaml:"lintBaseline,omitempty"`
+	Diff                   Diff                   `yaml:"diff,omitempty"`
+	Coverage               Coverage               `yaml:"coverage,omitempty"`
+	Renames                []*schema.RenameHint   `yaml:"renames,omitempty"`
 	Viewpoints             []Viewpoint            `yaml:"viewpoints,omitempty"`
 	Relations              []AdditionalRelation   `yaml:"relations,omitempty"`
 	Comments               []AdditionalComment    `yaml:"comments,omitempty"`
@@ -78,6 +85,8 @@ type Format struct {
 	Number                   bool     `yaml:"number,omitempty"`
 	ShowOnlyFirstParagraph   bool     `yaml:"showOnlyFirstParagraph,omitempty"`
 	HideColumnsWithoutValues []string `yaml:"hideColumnsWithoutValues,omitempty"`
+	History                  bool     `yaml:"history,omitempty"`
+	CoverageTrend            bool     `yaml:"coverageTrend,omitempty"`
 }
 
 // ER is er setting.
@@ -89,6 +98,7 @@ type ER struct {
 	ShowColumnTypes *ShowColumnTypes `yaml:"showColumnTypes,omitempty"`
 	Distance        *int             `yaml:"distance,omitempty"`
 	Font            string           `yaml:"font,omitempty"`
+	Viewer          bool             `yaml:"viewer,omitempty"`
 }
 
 // ShowColumnTypes is show column setting for ER diagram.
@@ -322,6 +332,20 @@ func (c *Config) validate() error {
 	if !lo.Contains(SupportERFormat, c.ER.Format) {
 		return fmt.Errorf("unsupported ER format: %s", c.ER.Format)
 	}
+	if err := c.Lint.validate(); err != nil {
+		return err
+	}
+	if err := c.Diff.Policy.validate(); err != nil {
+		return err
+	}
+	if err := c.Coverage.validate(); err != nil {
+		return err
+	}
+	for i, r := range c.Renames {
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("renames[%d] from and to are required", i)
+		}
+	}
 	for i, v := range c.Viewpoints {
 		if v.Name == "" {
 			return fmt.Errorf("viewpoints[%d] name is required", i)
@@ -424,6 +448,7 @@ func (c *Config) ModifySchema(s *schema.Schema) error {
 	if err := c.MergeAdditionalData(s); err != nil {
 		return err
 	}
+	s.ExtractLintIgnore()
 	if err := c.FilterTables(s); err != nil {
 		return err
 	}
@@ -584,6 +609,10 @@ func (c *Config) SchemaFilePath() string {
 	return filepath.Join(c.DocPath, SchemaFileName)
 }
 
+func (c *Config) ERViewerFilePath() string {
+	return filepath.Join(c.DocPath, ERViewerFileName)
+}
+
 func (c *Config) NeedToGenerateERImages() bool {
 	if c.ER.Skip {
 		return false
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// Coverage is the thresholds of `tbls coverage`.
+type Coverage struct {
+	// FailUnder is the minimum coverage of all tables.
+	FailUnder  float64             `yaml:"failUnder,omitempty"`
+	Tables     []CoverageThreshold `yaml:"tables,omitempty"`
+	Labels     []CoverageThreshold `yaml:"labels,omitempty"`
+	Viewpoints []CoverageThreshold `yaml:"viewpoints,omitempty"`
+}
+
+// CoverageThreshold is the minimum coverage of the tables, the table labels or the viewpoints matched by the name ( wildcards can be used ).
+type CoverageThreshold struct {
+	Name      string  `yaml:"name"`
+	FailUnder float64 `yaml:"failUnder"`
+}
+
+// Match return whether the threshold is for the name.
+func (t CoverageThreshold) Match(name string) bool {
+	return match([]string{t.Name}, name)
+}
+
+func (c Coverage) validate() error {
+	if c.FailUnder < 0 || c.FailUnder > 100 {
+		return fmt.Errorf("coverage.failUnder must be between 0 and 100: %g", c.FailUnder)
+	}
+	for _, kt := range []lo.Tuple2[string, []CoverageThreshold]{
+		lo.T2("tables", c.Tables),
+		lo.T2("labels", c.Labels),
+		lo.T2("viewpoints", c.Viewpoints),
+	} {
+		k, ths := kt.Unpack()
+		for i, th := range ths {
+			if th.Name == "" {
+				return fmt.Errorf("coverage.%s[%d] name is required", k, i)
+			}
+			if th.FailUnder < 0 || th.FailUnder > 100 {
+				return fmt.Errorf("coverage.%s[%d] failUnder must be between 0 and 100: %g", k, i, th.FailUnder)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+func TestCoverageValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		coverage Coverage
+		wantErr  bool
+	}{
+		{"empty", Coverage{}, false},
+		{"valid", Coverage{FailUnder: 80, Tables: []CoverageThreshold{{Name: "users", FailUnder: 90}}, Labels: []CoverageThreshold{{Name: "team-*", FailUnder: 50}}}, false},
+		{"failUnder over 100", Coverage{FailUnder: 101}, true},
+		{"no name", Coverage{Viewpoints: []CoverageThreshold{{FailUnder: 50}}}, true},
+		{"negative failUnder", Coverage{Labels: []CoverageThreshold{{Name: "team-a", FailUnder: -1}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.coverage.validate(); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got error: %s", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+		})
+	}
+}
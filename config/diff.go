@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// Diff is the struct for diff config.
+type Diff struct {
+	Policy DiffPolicy `yaml:"policy,omitempty"`
+}
+
+// DiffPolicy is the policy of `tbls diff` for schema changes.
+type DiffPolicy struct {
+	// Classes of changes that fail `tbls diff`. Default is all classes.
+	Fail []schema.ChangeClass `yaml:"fail,omitempty"`
+}
+
+// IsFailure return whether the class of changes fails `tbls diff`.
+func (p DiffPolicy) IsFailure(class schema.ChangeClass) bool {
+	if p.Fail == nil {
+		return true
+	}
+	return lo.Contains(p.Fail, class)
+}
+
+func (p DiffPolicy) validate() error {
+	for i, class := range p.Fail {
+		if !lo.Contains(schema.ChangeClasses, class) {
+			return fmt.Errorf("diff.policy.fail[%d] has unknown class '%s'", i, class)
+		}
+	}
+	return nil
+}
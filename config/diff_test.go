@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+func TestDiffPolicyIsFailure(t *testing.T) {
+	tests := []struct {
+		fail  []schema.ChangeClass
+		class schema.ChangeClass
+		want  bool
+	}{
+		{nil, schema.ChangeClassAdditive, true},
+		{nil, schema.ChangeClassBreaking, true},
+		{[]schema.ChangeClass{schema.ChangeClassBreaking}, schema.ChangeClassBreaking, true},
+		{[]schema.ChangeClass{schema.ChangeClassBreaking}, schema.ChangeClassCompatible, false},
+		{[]schema.ChangeClass{}, schema.ChangeClassBreaking, false},
+	}
+	for _, tt := range tests {
+		p := DiffPolicy{Fail: tt.fail}
+		if got := p.IsFailure(tt.class); got != tt.want {
+			t.Errorf("fail %v, class %s: got %v\nwant %v", tt.fail, tt.class, got, tt.want)
+		}
+	}
+}
+
+func TestDiffPolicyValidate(t *testing.T) {
+	p := DiffPolicy{Fail: []schema.ChangeClass{schema.ChangeClassBreaking, "unknown"}}
+	if err := p.validate(); err == nil {
+		t.Error("want error")
+	}
+}
@@ -2,11 +2,14 @@ package config
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
 )
 
 // Lint is the struct for lint config.
@@ -24,12 +27,153 @@ type Lint struct {
 	RequireForeignKeyIndex   RequireForeignKeyIndex   `yaml:"requireForeignKeyIndex"`
 	LabelStyleBigQuery       LabelStyleBigQuery       `yaml:"labelStyleBigQuery"`
 	RequireViewpoints        RequireViewpoints        `yaml:"requireViewpoints"`
+	NamingConvention         NamingConvention         `yaml:"namingConvention"`
+	RequirePrimaryKey        RequirePrimaryKey        `yaml:"requirePrimaryKey"`
+	ForeignKeyTypeMismatch   ForeignKeyTypeMismatch   `yaml:"foreignKeyTypeMismatch"`
+	RedundantIndex           RedundantIndex           `yaml:"redundantIndex"`
+	NullableForeignKey       NullableForeignKey       `yaml:"nullableForeignKey"`
+	Custom                   CustomRules              `yaml:"custom,omitempty"`
 }
 
+// Severity is the severity of RuleWarn.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Severities is the list of severities.
+var Severities = []Severity{SeverityError, SeverityWarning, SeverityInfo}
+
 // RuleWarn is struct of Rule error.
 type RuleWarn struct {
-	Target  string
-	Message string
+	// Rule is the name of the rule ( the key in `lint:` ).
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Target is the name of the target object such as `table`, `table.column` and `table.index`.
+	Target string `json:"target"`
+	// Table, Column, Index, Constraint and Trigger are the names of the target object and the table it belongs to.
+	Table      string `json:"table,omitempty"`
+	Column     string `json:"column,omitempty"`
+	Index      string `json:"index,omitempty"`
+	Constraint string `json:"constraint,omitempty"`
+	Trigger    string `json:"trigger,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Check return the warnings of the rules with the rule name and the severity of the rule ( default: error ).
+func (l Lint) Check(s *schema.Schema, exclude []string) ([]RuleWarn, error) {
+	v := reflect.ValueOf(l)
+	t := v.Type()
+	warns := []RuleWarn{}
+	for i := 0; i < t.NumField(); i++ {
+		if cr, ok := v.Field(i).Interface().(CustomRules); ok {
+			cw, err := cr.Check(s, exclude)
+			if err != nil {
+				return nil, err
+			}
+			warns = append(warns, ignored(s, cw)...)
+			continue
+		}
+		r, ok := v.Field(i).Interface().(Rule)
+		if !ok {
+			return nil, fmt.Errorf("invalid rule: %v", v.Field(i).Interface())
+		}
+		name := ruleName(t.Field(i))
+		severity := ruleSeverity(v.Field(i))
+		for _, w := range r.Check(s, exclude) {
+			w.Rule = name
+			if w.Severity == "" {
+				w.Severity = severity
+			}
+			warns = append(warns, w)
+		}
+	}
+	return ignored(s, warns), nil
+}
+
+// ignored return the warnings without the warnings ignored by `tbls:lint-ignore` markers in the comments of the tables and columns.
+func ignored(s *schema.Schema, warns []RuleWarn) []RuleWarn {
+	return lo.Filter(warns, func(w RuleWarn, _ int) bool {
+		if w.Table == "" {
+			return true
+		}
+		t, err := s.FindTableByName(w.Table)
+		if err != nil {
+			return true
+		}
+		if match(t.LintIgnore, w.Rule) {
+			return false
+		}
+		if w.Column == "" {
+			return true
+		}
+		c, err := t.FindColumnByName(w.Column)
+		if err != nil {
+			return true
+		}
+		return !match(c.LintIgnore, w.Rule)
+	})
+}
+
+// HasError return whether the warnings contain errors.
+func HasError(warns []RuleWarn) bool {
+	for _, w := range warns {
+		if w.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (l Lint) validate() error {
+	if err := l.NamingConvention.validate(); err != nil {
+		return err
+	}
+	if err := l.Custom.validate(); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(l)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if v.Field(i).Kind() != reflect.Struct {
+			continue
+		}
+		f := v.Field(i).FieldByName("Severity")
+		if !f.IsValid() {
+			continue
+		}
+		if sv := Severity(f.String()); sv != "" && !lo.Contains(Severities, sv) {
+			return fmt.Errorf("lint.%s.severity has unknown severity '%s'", ruleName(t.Field(i)), sv)
+		}
+	}
+	return nil
+}
+
+// builtinRuleNames return the names of the built-in rules.
+func builtinRuleNames() []string {
+	names := []string{}
+	t := reflect.TypeOf(Lint{})
+	rt := reflect.TypeOf((*Rule)(nil)).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type.Implements(rt) {
+			names = append(names, ruleName(t.Field(i)))
+		}
+	}
+	return names
+}
+
+func ruleName(f reflect.StructField) string {
+	return strings.Split(f.Tag.Get("yaml"), ",")[0]
+}
+
+func ruleSeverity(r reflect.Value) Severity {
+	if f := r.FieldByName("Severity"); f.IsValid() && f.String() != "" {
+		return Severity(f.String())
+	}
+	return SeverityError
 }
 
 // Rule is interfece of `tbls lint` cop.
@@ -41,6 +185,7 @@ type Rule interface {
 // RequireTableComment checks table comment.
 type RequireTableComment struct {
 	Enabled      bool     `yaml:"enabled"`
+	Severity     Severity `yaml:"severity,omitempty"`
 	AllOrNothing bool     `yaml:"allOrNothing"`
 	Exclude      []string `yaml:"exclude"`
 }
@@ -71,6 +216,7 @@ func (r RequireTableComment) Check(s *schema.Schema, exclude []string) []RuleWar
 		if t.Comment == "" {
 			warns = append(warns, RuleWarn{
 				Target:  t.Name,
+				Table:   t.Name,
 				Message: msg,
 			})
 			continue
@@ -86,6 +232,7 @@ func (r RequireTableComment) Check(s *schema.Schema, exclude []string) []RuleWar
 // RequireColumnComment checks column comment.
 type RequireColumnComment struct {
 	Enabled       bool     `yaml:"enabled"`
+	Severity      Severity `yaml:"severity,omitempty"`
 	AllOrNothing  bool     `yaml:"allOrNothing"`
 	Exclude       []string `yaml:"exclude"`
 	ExcludeTables []string `yaml:"excludeTables"`
@@ -122,6 +269,8 @@ func (r RequireColumnComment) Check(s *schema.Schema, exclude []string) []RuleWa
 			if c.Comment == "" {
 				warns = append(warns, RuleWarn{
 					Target:  target,
+					Table:   t.Name,
+					Column:  c.Name,
 					Message: msg,
 				})
 				continue
@@ -138,6 +287,7 @@ func (r RequireColumnComment) Check(s *schema.Schema, exclude []string) []RuleWa
 // RequireIndexComment checks index comment.
 type RequireIndexComment struct {
 	Enabled       bool     `yaml:"enabled"`
+	Severity      Severity `yaml:"severity,omitempty"`
 	AllOrNothing  bool     `yaml:"allOrNothing"`
 	Exclude       []string `yaml:"exclude"`
 	ExcludeTables []string `yaml:"excludeTables"`
@@ -174,6 +324,8 @@ func (r RequireIndexComment) Check(s *schema.Schema, exclude []string) []RuleWar
 			if i.Comment == "" {
 				warns = append(warns, RuleWarn{
 					Target:  target,
+					Table:   t.Name,
+					Index:   i.Name,
 					Message: msg,
 				})
 				continue
@@ -190,6 +342,7 @@ func (r RequireIndexComment) Check(s *schema.Schema, exclude []string) []RuleWar
 // RequireConstraintComment checks constraint comment.
 type RequireConstraintComment struct {
 	Enabled       bool     `yaml:"enabled"`
+	Severity      Severity `yaml:"severity,omitempty"`
 	AllOrNothing  bool     `yaml:"allOrNothing"`
 	Exclude       []string `yaml:"exclude"`
 	ExcludeTables []string `yaml:"excludeTables"`
@@ -225,8 +378,10 @@ func (r RequireConstraintComment) Check(s *schema.Schema, exclude []string) []Ru
 			}
 			if c.Comment == "" {
 				warns = append(warns, RuleWarn{
-					Target:  target,
-					Message: msg,
+					Target:     target,
+					Table:      t.Name,
+					Constraint: c.Name,
+					Message:    msg,
 				})
 				continue
 			}
@@ -242,6 +397,7 @@ func (r RequireConstraintComment) Check(s *schema.Schema, exclude []string) []Ru
 // RequireTriggerComment checks trigger comment.
 type RequireTriggerComment struct {
 	Enabled       bool     `yaml:"enabled"`
+	Severity      Severity `yaml:"severity,omitempty"`
 	AllOrNothing  bool     `yaml:"allOrNothing"`
 	Exclude       []string `yaml:"exclude"`
 	ExcludeTables []string `yaml:"excludeTables"`
@@ -278,6 +434,8 @@ func (r RequireTriggerComment) Check(s *schema.Schema, exclude []string) []RuleW
 			if trig.Comment == "" {
 				warns = append(warns, RuleWarn{
 					Target:  target,
+					Table:   t.Name,
+					Trigger: trig.Name,
 					Message: msg,
 				})
 				continue
@@ -294,6 +452,7 @@ func (r RequireTriggerComment) Check(s *schema.Schema, exclude []string) []RuleW
 // RequireTableLabels checks table labels.
 type RequireTableLabels struct {
 	Enabled      bool     `yaml:"enabled"`
+	Severity     Severity `yaml:"severity,omitempty"`
 	AllOrNothing bool     `yaml:"allOrNothing"`
 	Exclude      []string `yaml:"exclude"`
 }
@@ -325,6 +484,7 @@ func (r RequireTableLabels) Check(s *schema.Schema, exclude []string) []RuleWarn
 			target := t.Name
 			warns = append(warns, RuleWarn{
 				Target:  target,
+				Table:   t.Name,
 				Message: msg,
 			})
 			continue
@@ -341,6 +501,7 @@ func (r RequireTableLabels) Check(s *schema.Schema, exclude []string) []RuleWarn
 // UnrelatedTable checks isolated table.
 type UnrelatedTable struct {
 	Enabled      bool     `yaml:"enabled"`
+	Severity     Severity `yaml:"severity,omitempty"`
 	AllOrNothing bool     `yaml:"allOrNothing"`
 	Exclude      []string `yaml:"exclude"`
 }
@@ -368,6 +529,10 @@ func (r UnrelatedTable) Check(s *schema.Schema, exclude []string) []RuleWarn {
 		if match(nt, t.Name) {
 			continue
 		}
+		// The warning of this rule is for the schema, so the marker of the table is checked here.
+		if match(t.LintIgnore, "unrelatedTable") {
+			continue
+		}
 		ut[t.Name] = t
 	}
 	before := len(ut)
@@ -384,6 +549,7 @@ func (r UnrelatedTable) Check(s *schema.Schema, exclude []string) []RuleWarn {
 		for _, t := range ut {
 			us = append(us, t.Name)
 		}
+		sort.Strings(us)
 		warns = append(warns, RuleWarn{
 			Target:  s.Name,
 			Message: fmt.Sprintf(msgFmt, us),
@@ -397,9 +563,10 @@ func (r UnrelatedTable) Check(s *schema.Schema, exclude []string) []RuleWarn {
 
 // ColumnCount checks table column count.
 type ColumnCount struct {
-	Enabled bool     `yaml:"enabled"`
-	Max     int      `yaml:"max"`
-	Exclude []string `yaml:"exclude"`
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Max      int      `yaml:"max"`
+	Exclude  []string `yaml:"exclude"`
 }
 
 // IsEnabled return Rule is enabled or not.
@@ -426,6 +593,7 @@ func (r ColumnCount) Check(s *schema.Schema, exclude []string) []RuleWarn {
 		if len(t.Columns) > r.Max {
 			warns = append(warns, RuleWarn{
 				Target:  t.Name,
+				Table:   t.Name,
 				Message: fmt.Sprintf(msgFmt, len(t.Columns), r.Max),
 			})
 		}
@@ -435,8 +603,9 @@ func (r ColumnCount) Check(s *schema.Schema, exclude []string) []RuleWarn {
 
 // RequireColumns checks if the table has specified columns.
 type RequireColumns struct {
-	Enabled bool                   `yaml:"enabled"`
-	Columns []RequireColumnsColumn `yaml:"columns"`
+	Enabled  bool                   `yaml:"enabled"`
+	Severity Severity               `yaml:"severity,omitempty"`
+	Columns  []RequireColumnsColumn `yaml:"columns"`
 }
 
 // RequireColumnsColumn is required column.
@@ -478,6 +647,7 @@ func (r RequireColumns) Check(s *schema.Schema, exclude []string) []RuleWarn {
 			if !exists {
 				warns = append(warns, RuleWarn{
 					Target:  t.Name,
+					Table:   t.Name,
 					Message: fmt.Sprintf(msgFmt, cc.Name),
 				})
 			}
@@ -488,7 +658,8 @@ func (r RequireColumns) Check(s *schema.Schema, exclude []string) []RuleWarn {
 
 // DuplicateRelations checks duplicate table relations.
 type DuplicateRelations struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
 }
 
 // IsEnabled return Rule is enabled or not.
@@ -527,6 +698,7 @@ func (r DuplicateRelations) Check(s *schema.Schema, exclude []string) []RuleWarn
 		if _, dup := relations[key]; dup {
 			warns = append(warns, RuleWarn{
 				Target:  r.Table.Name,
+				Table:   r.Table.Name,
 				Message: fmt.Sprintf(msgFmt, r.Table.Name, r.ParentTable.Name),
 			})
 		}
@@ -538,8 +710,9 @@ func (r DuplicateRelations) Check(s *schema.Schema, exclude []string) []RuleWarn
 
 // RequireForeignKeyIndex checks if the foreign key columns have an index.
 type RequireForeignKeyIndex struct {
-	Enabled bool     `yaml:"enabled"`
-	Exclude []string `yaml:"exclude"`
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude"`
 }
 
 // IsEnabled return Rule is enabled or not.
@@ -579,6 +752,8 @@ func (r RequireForeignKeyIndex) Check(s *schema.Schema, exclude []string) []Rule
 				if !exist {
 					warns = append(warns, RuleWarn{
 						Target:  target,
+						Table:   t.Name,
+						Column:  c1,
 						Message: fmt.Sprintf(msgFmt, t.Name),
 					})
 				}
@@ -591,8 +766,9 @@ func (r RequireForeignKeyIndex) Check(s *schema.Schema, exclude []string) []Rule
 
 // LabelStyleBigQuery checks if labels are in BigQuery style ( https://cloud.google.com/resource-manager/docs/creating-managing-labels#requirements ).
 type LabelStyleBigQuery struct {
-	Enabled bool     `yaml:"enabled"`
-	Exclude []string `yaml:"exclude"`
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude"`
 }
 
 // IsEnabled return Rule is enabled or not.
@@ -632,6 +808,7 @@ func (r LabelStyleBigQuery) Check(s *schema.Schema, exclude []string) []RuleWarn
 				target := fmt.Sprintf("%s.Labels.%s", t.Name, l.Name)
 				warns = append(warns, RuleWarn{
 					Target:  target,
+					Table:   t.Name,
 					Message: fmt.Sprintf(msgFmt, l.Name, t.Name),
 				})
 			}
@@ -668,8 +845,9 @@ func checkLabelStyleBigQuery(label string) bool {
 
 // RequireViewpoints checks if the table is included in any viewpoints.
 type RequireViewpoints struct {
-	Enabled bool     `yaml:"enabled"`
-	Exclude []string `yaml:"exclude"`
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude"`
 }
 
 // IsEnabled return Rule is enabled or not.
@@ -704,9 +882,216 @@ T:
 		}
 		warns = append(warns, RuleWarn{
 			Target:  t.Name,
+			Table:   t.Name,
 			Message: fmt.Sprintf(msgFmt, t.Name),
 		})
 	}
 
 	return warns
 }
+
+// RequirePrimaryKey checks if the base table has a primary key.
+type RequirePrimaryKey struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r RequirePrimaryKey) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check if the base table has a primary key.
+func (r RequirePrimaryKey) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msg := "primary key required."
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		// SQLite reports base tables as `table`
+		if !strings.EqualFold(t.Type, "BASE TABLE") && !strings.EqualFold(t.Type, "table") {
+			continue
+		}
+		if len(t.PrimaryKeyColumns()) == 0 {
+			warns = append(warns, RuleWarn{
+				Target:  t.Name,
+				Table:   t.Name,
+				Message: msg,
+			})
+		}
+	}
+
+	return warns
+}
+
+// ForeignKeyTypeMismatch checks if the types of the foreign key columns match the types of the parent columns.
+type ForeignKeyTypeMismatch struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r ForeignKeyTypeMismatch) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check if the types of the foreign key columns match the types of the parent columns.
+func (r ForeignKeyTypeMismatch) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "foreign key column type does not match the parent column type. [%s %s -> %s.%s %s]"
+
+	for _, rl := range s.Relations {
+		if rl.Virtual {
+			continue
+		}
+		if match(exclude, rl.Table.Name) {
+			continue
+		}
+		for i, c := range rl.Columns {
+			if i >= len(rl.ParentColumns) {
+				break
+			}
+			pc := rl.ParentColumns[i]
+			target := fmt.Sprintf("%s.%s", rl.Table.Name, c.Name)
+			if match(r.Exclude, c.Name) || match(r.Exclude, target) {
+				continue
+			}
+			if strings.EqualFold(strings.TrimSpace(c.Type), strings.TrimSpace(pc.Type)) {
+				continue
+			}
+			warns = append(warns, RuleWarn{
+				Target:  target,
+				Table:   rl.Table.Name,
+				Column:  c.Name,
+				Message: fmt.Sprintf(msgFmt, target, c.Type, rl.ParentTable.Name, pc.Name, pc.Type),
+			})
+		}
+	}
+
+	return warns
+}
+
+// RedundantIndex checks if the index is redundant because its columns are a left prefix of the columns of another index.
+type RedundantIndex struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r RedundantIndex) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check if the index is redundant.
+func (r RedundantIndex) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "redundant index. [%s is a left prefix of %s]"
+
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		for i, a := range t.Indexes {
+			target := fmt.Sprintf("%s.%s", t.Name, a.Name)
+			if match(r.Exclude, a.Name) || match(r.Exclude, target) {
+				continue
+			}
+			if len(a.Columns) == 0 || isUniqueIndex(a) {
+				// Unique indexes enforce uniqueness of their columns.
+				continue
+			}
+			for j, b := range t.Indexes {
+				if i == j || len(a.Columns) > len(b.Columns) {
+					continue
+				}
+				if !slices.Equal(a.Columns, b.Columns[:len(a.Columns)]) {
+					continue
+				}
+				if len(a.Columns) == len(b.Columns) && !isUniqueIndex(b) && i < j {
+					// Of the indexes with the same columns, the latter is reported.
+					continue
+				}
+				warns = append(warns, RuleWarn{
+					Target:  target,
+					Table:   t.Name,
+					Index:   a.Name,
+					Message: fmt.Sprintf(msgFmt, a.Name, b.Name),
+				})
+				break
+			}
+		}
+	}
+
+	return warns
+}
+
+func isUniqueIndex(i *schema.Index) bool {
+	def := strings.ToUpper(i.Def)
+	return strings.Contains(def, "UNIQUE") || strings.Contains(def, "PRIMARY KEY")
+}
+
+// NullableForeignKey checks if the foreign key columns are nullable.
+type NullableForeignKey struct {
+	Enabled  bool     `yaml:"enabled"`
+	Severity Severity `yaml:"severity,omitempty"`
+	// Allow is the list of columns ( `column` or `table.column` ) allowed to be nullable.
+	Allow []string `yaml:"allow"`
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r NullableForeignKey) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check if the foreign key columns are nullable.
+func (r NullableForeignKey) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+	msgFmt := "foreign key column is nullable. [%s -> %s]"
+
+	for _, rl := range s.Relations {
+		if rl.Virtual {
+			continue
+		}
+		if match(exclude, rl.Table.Name) {
+			continue
+		}
+		for _, c := range rl.Columns {
+			target := fmt.Sprintf("%s.%s", rl.Table.Name, c.Name)
+			if match(r.Allow, c.Name) || match(r.Allow, target) {
+				continue
+			}
+			if !c.Nullable {
+				continue
+			}
+			warns = append(warns, RuleWarn{
+				Target:  target,
+				Table:   rl.Table.Name,
+				Column:  c.Name,
+				Message: fmt.Sprintf(msgFmt, target, rl.ParentTable.Name),
+			})
+		}
+	}
+
+	return warns
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/errors"
+	"github.com/samber/lo"
+)
+
+// DefaultLintBaselinePath is the default path of the baseline file of `tbls lint`.
+const DefaultLintBaselinePath = ".tbls.lint-baseline.yml"
+
+// volatileMessageRules is the rules whose messages change without a new violation ( e.g. the number of columns ).
+// Their warnings are matched by Rule and Target only.
+var volatileMessageRules = []string{"columnCount"}
+
+// LintBaseline is the struct for the warnings of `tbls lint` recorded as the baseline.
+type LintBaseline struct {
+	Warns []LintBaselineWarn `yaml:"warns"`
+}
+
+// LintBaselineWarn is the recorded warning. Warnings are matched by Rule, Target and Message ( except for the rules in volatileMessageRules ).
+type LintBaselineWarn struct {
+	Rule    string `yaml:"rule"`
+	Target  string `yaml:"target"`
+	Message string `yaml:"message,omitempty"`
+}
+
+// NewLintBaseline return LintBaseline that records the warnings.
+func NewLintBaseline(warns []RuleWarn) *LintBaseline {
+	b := &LintBaseline{
+		Warns: []LintBaselineWarn{},
+	}
+	for _, w := range warns {
+		b.Warns = append(b.Warns, LintBaselineWarn{
+			Rule:    w.Rule,
+			Target:  w.Target,
+			Message: w.Message,
+		})
+	}
+	return b
+}
+
+// LoadLintBaseline load the baseline file. If the file does not exist, it return an empty baseline.
+func LoadLintBaseline(path string) (*LintBaseline, error) {
+	b := &LintBaseline{
+		Warns: []LintBaselineWarn{},
+	}
+	buf, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	if err := yaml.Unmarshal(buf, b); err != nil {
+		return nil, fmt.Errorf("failed to load lint baseline %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Write write the baseline file.
+func (b *LintBaseline) Write(path string) error {
+	buf, err := yaml.Marshal(b)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), buf, 0644); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Subtract return the warnings that are not recorded in the baseline.
+// Warnings are also matched by Message, because a target can have several violations of the same rule
+// ( such as requireColumns and namingConvention ) and Message of schema-level rules ( such as unrelatedTable ) holds the tables that violate the rule.
+func (b *LintBaseline) Subtract(warns []RuleWarn) []RuleWarn {
+	recorded := map[[3]string]struct{}{}
+	for _, w := range b.Warns {
+		recorded[baselineKey(w.Rule, w.Target, w.Message)] = struct{}{}
+	}
+	subtracted := []RuleWarn{}
+	for _, w := range warns {
+		if _, ok := recorded[baselineKey(w.Rule, w.Target, w.Message)]; ok {
+			continue
+		}
+		subtracted = append(subtracted, w)
+	}
+	return subtracted
+}
+
+func baselineKey(rule, target, message string) [3]string {
+	if lo.Contains(volatileMessageRules, rule) {
+		message = ""
+	}
+	return [3]string{rule, target, message}
+}
+
+// LintBaselinePath return the path of the baseline file of `tbls lint`.
+func (c *Config) LintBaselinePath() string {
+	if c.LintBaseline != "" {
+		return c.LintBaseline
+	}
+	return DefaultLintBaselinePath
+}
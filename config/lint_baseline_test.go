@@ -0,0 +1,90 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintBaseline(t *testing.T) {
+	recorded := []RuleWarn{
+		{Rule: "requireColumnComment", Severity: SeverityError, Target: "users.email", Table: "users", Column: "email", Message: "column comment required."},
+		{Rule: "columnCount", Severity: SeverityError, Target: "posts", Table: "posts", Message: "too many columns. [12/10]"},
+	}
+	p := filepath.Join(t.TempDir(), DefaultLintBaselinePath)
+	if err := NewLintBaseline(recorded).Write(p); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadLintBaseline(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	warns := []RuleWarn{
+		{Rule: "requireColumnComment", Severity: SeverityError, Target: "users.email", Table: "users", Column: "email", Message: "column comment required."},
+		{Rule: "requireColumnComment", Severity: SeverityError, Target: "users.name", Table: "users", Column: "name", Message: "column comment required."},
+		{Rule: "columnCount", Severity: SeverityError, Target: "posts", Table: "posts", Message: "too many columns. [13/10]"},
+		{Rule: "requireTableComment", Severity: SeverityError, Target: "posts", Table: "posts", Message: "table comment required."},
+	}
+	got := b.Subtract(warns)
+	want := []RuleWarn{warns[1], warns[3]}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLintBaselineSchemaLevelRule(t *testing.T) {
+	b := NewLintBaseline([]RuleWarn{
+		{Rule: "unrelatedTable", Severity: SeverityError, Target: "testdb", Message: "unrelated (isolated) table exists. [logs]"},
+	})
+	tests := []struct {
+		name  string
+		warns []RuleWarn
+		want  int
+	}{
+		{
+			"same tables",
+			[]RuleWarn{{Rule: "unrelatedTable", Severity: SeverityError, Target: "testdb", Message: "unrelated (isolated) table exists. [logs]"}},
+			0,
+		},
+		{
+			"new table",
+			[]RuleWarn{{Rule: "unrelatedTable", Severity: SeverityError, Target: "testdb", Message: "unrelated (isolated) table exists. [logs tags]"}},
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := b.Subtract(tt.warns)
+			if len(got) != tt.want {
+				t.Errorf("got %v\nwant %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLintBaselineRequireColumns(t *testing.T) {
+	b := NewLintBaseline([]RuleWarn{
+		{Rule: "requireColumns", Severity: SeverityError, Target: "users", Table: "users", Message: "column 'created' required."},
+	})
+	warns := []RuleWarn{
+		{Rule: "requireColumns", Severity: SeverityError, Target: "users", Table: "users", Message: "column 'created' required."},
+		{Rule: "requireColumns", Severity: SeverityError, Target: "users", Table: "users", Message: "column 'updated' required."},
+	}
+	got := b.Subtract(warns)
+	want := []RuleWarn{warns[1]}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLoadLintBaselineNotExist(t *testing.T) {
+	b, err := LoadLintBaseline(filepath.Join(t.TempDir(), DefaultLintBaselinePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Warns) != 0 {
+		t.Errorf("got %v\nwant %v", len(b.Warns), 0)
+	}
+}
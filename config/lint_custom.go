@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+const (
+	CustomRuleTargetTable  = "table"
+	CustomRuleTargetColumn = "column"
+)
+
+// CustomRuleTargets is the list of targets of custom rules.
+var CustomRuleTargets = []string{CustomRuleTargetTable, CustomRuleTargetColumn}
+
+// CustomRules is the list of custom lint rules.
+type CustomRules []CustomRule
+
+// CustomRule is the lint rule written as an expression.
+// The expression is evaluated for each table ( or column ), and returns true when the table ( or column ) violates the rule.
+// The expression can refer to `schema`, `table` and `column` ( only for the `column` target ).
+type CustomRule struct {
+	Name     string   `yaml:"name"`
+	Target   string   `yaml:"target"`
+	Expr     string   `yaml:"expr"`
+	Message  string   `yaml:"message,omitempty"`
+	Severity Severity `yaml:"severity,omitempty"`
+	Exclude  []string `yaml:"exclude,omitempty"`
+}
+
+// Check the custom rules.
+func (rs CustomRules) Check(s *schema.Schema, exclude []string) ([]RuleWarn, error) {
+	warns := []RuleWarn{}
+	for _, r := range rs {
+		w, err := r.Check(s, exclude)
+		if err != nil {
+			return nil, err
+		}
+		warns = append(warns, w...)
+	}
+	return warns, nil
+}
+
+// Check the custom rule.
+func (r CustomRule) Check(s *schema.Schema, exclude []string) ([]RuleWarn, error) {
+	warns := []RuleWarn{}
+	program, err := r.compile()
+	if err != nil {
+		return nil, err
+	}
+	severity := r.Severity
+	if severity == "" {
+		severity = SeverityError
+	}
+	msg := r.Message
+	if msg == "" {
+		msg = fmt.Sprintf("custom rule `%s` violated.", r.Name)
+	}
+
+	nt := s.NormalizeTableNames(r.Exclude)
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		if match(nt, t.Name) {
+			continue
+		}
+		switch r.Target {
+		case CustomRuleTargetTable:
+			violated, err := r.run(program, map[string]interface{}{"schema": s, "table": t})
+			if err != nil {
+				return nil, err
+			}
+			if violated {
+				warns = append(warns, RuleWarn{
+					Rule:     r.Name,
+					Severity: severity,
+					Target:   t.Name,
+					Table:    t.Name,
+					Message:  msg,
+				})
+			}
+		case CustomRuleTargetColumn:
+			for _, c := range t.Columns {
+				target := fmt.Sprintf("%s.%s", t.Name, c.Name)
+				if match(r.Exclude, target) {
+					continue
+				}
+				violated, err := r.run(program, map[string]interface{}{"schema": s, "table": t, "column": c})
+				if err != nil {
+					return nil, err
+				}
+				if violated {
+					warns = append(warns, RuleWarn{
+						Rule:     r.Name,
+						Severity: severity,
+						Target:   target,
+						Table:    t.Name,
+						Column:   c.Name,
+						Message:  msg,
+					})
+				}
+			}
+		}
+	}
+	return warns, nil
+}
+
+func (r CustomRule) compile() (*vm.Program, error) {
+	env := map[string]interface{}{
+		"schema": &schema.Schema{},
+		"table":  &schema.Table{},
+	}
+	if r.Target == CustomRuleTargetColumn {
+		env["column"] = &schema.Column{}
+	}
+	program, err := expr.Compile(r.Expr, expr.Env(env), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("invalid expr of custom rule '%s': %w", r.Name, err)
+	}
+	return program, nil
+}
+
+func (r CustomRule) run(program *vm.Program, env map[string]interface{}) (bool, error) {
+	result, err := expr.Run(program, env)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate custom rule '%s': %w", r.Name, err)
+	}
+	violated, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("custom rule '%s': expected bool, but got %T", r.Name, result)
+	}
+	return violated, nil
+}
+
+func (rs CustomRules) validate() error {
+	names := map[string]struct{}{}
+	for i, r := range rs {
+		if r.Name == "" {
+			return fmt.Errorf("lint.custom[%d] name is required", i)
+		}
+		if _, ok := names[r.Name]; ok {
+			return fmt.Errorf("lint.custom[%d] name '%s' is duplicated", i, r.Name)
+		}
+		names[r.Name] = struct{}{}
+		if lo.Contains(builtinRuleNames(), r.Name) {
+			return fmt.Errorf("lint.custom[%d] name '%s' is the name of a built-in rule", i, r.Name)
+		}
+		if !lo.Contains(CustomRuleTargets, r.Target) {
+			return fmt.Errorf("lint.custom[%d] has unknown target '%s'", i, r.Target)
+		}
+		if r.Severity != "" && !lo.Contains(Severities, r.Severity) {
+			return fmt.Errorf("lint.custom[%d] has unknown severity '%s'", i, r.Severity)
+		}
+		if _, err := r.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
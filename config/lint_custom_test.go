@@ -0,0 +1,145 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCustomRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        CustomRule
+		lintExclude []string
+		want        []string
+	}{
+		{
+			"column",
+			CustomRule{Name: "nullable-text", Target: "column", Expr: "column.Type == 'text' && column.Nullable"},
+			[]string{},
+			[]string{"table_b.column_b1", "table_b.column_b2"},
+		},
+		{
+			"column with regexp",
+			CustomRule{Name: "c-columns", Target: "column", Expr: "column.Name matches '^column_c[12]$'"},
+			[]string{},
+			[]string{"table_c.column_c1", "table_c.column_c2"},
+		},
+		{
+			"table",
+			CustomRule{Name: "wide-table", Target: "table", Expr: "len(table.Columns) > 2"},
+			[]string{},
+			[]string{"table_c"},
+		},
+		{
+			"table with schema",
+			CustomRule{Name: "uncommented-table", Target: "table", Expr: "table.Comment == '' && len(schema.Tables) > 1"},
+			[]string{},
+			[]string{"table_a"},
+		},
+		{
+			"exclude table",
+			CustomRule{Name: "nullable-text", Target: "column", Expr: "column.Nullable", Exclude: []string{"table_b"}},
+			[]string{},
+			[]string{},
+		},
+		{
+			"exclude column",
+			CustomRule{Name: "nullable-text", Target: "column", Expr: "column.Nullable", Exclude: []string{"table_b.column_b1"}},
+			[]string{},
+			[]string{"table_b.column_b2"},
+		},
+		{
+			"lintExclude",
+			CustomRule{Name: "wide-table", Target: "table", Expr: "len(table.Columns) > 2"},
+			[]string{"table_c"},
+			[]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSchema(t)
+			warns, err := CustomRules{tt.rule}.Check(s, tt.lintExclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, w := range warns {
+				if w.Rule != tt.rule.Name {
+					t.Errorf("got %v\nwant %v", w.Rule, tt.rule.Name)
+				}
+				if w.Severity != SeverityError {
+					t.Errorf("got %v\nwant %v", w.Severity, SeverityError)
+				}
+				got = append(got, w.Target)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCustomRulesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   CustomRules
+		wantErr bool
+	}{
+		{"valid", CustomRules{{Name: "a", Target: "column", Expr: "column.Type in ['float', 'double']"}}, false},
+		{"no name", CustomRules{{Target: "column", Expr: "true"}}, true},
+		{"duplicate name", CustomRules{{Name: "a", Target: "table", Expr: "true"}, {Name: "a", Target: "table", Expr: "true"}}, true},
+		{"built-in rule name", CustomRules{{Name: "requireTableComment", Target: "table", Expr: "table.Comment == ''"}}, true},
+		{"unknown target", CustomRules{{Name: "a", Target: "index", Expr: "true"}}, true},
+		{"unknown severity", CustomRules{{Name: "a", Target: "table", Expr: "true", Severity: "fatal"}}, true},
+		{"invalid expr", CustomRules{{Name: "a", Target: "table", Expr: "table.Unknown == 1"}}, true},
+		{"column in table target", CustomRules{{Name: "a", Target: "table", Expr: "column.Nullable"}}, true},
+		{"not bool", CustomRules{{Name: "a", Target: "table", Expr: "table.Name"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.validate(); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got error: %s", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+		})
+	}
+}
+
+func TestLoadCustomRules(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfig([]byte(`
+lint:
+  custom:
+    - name: money-not-float
+      target: column
+      expr: "column.Type in ['float', 'double'] && column.Name matches '.*(price|amount).*'"
+      message: use decimal for money.
+      severity: warning
+`)); err != nil {
+		t.Fatal(err)
+	}
+	want := CustomRules{
+		{
+			Name:     "money-not-float",
+			Target:   "column",
+			Expr:     "column.Type in ['float', 'double'] && column.Name matches '.*(price|amount).*'",
+			Message:  "use decimal for money.",
+			Severity: SeverityWarning,
+		},
+	}
+	if diff := cmp.Diff(c.Lint.Custom, want); diff != "" {
+		t.Error(diff)
+	}
+	if err := c.Lint.validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// Presets of NamingConventionPattern.
+const (
+	NamingPresetSnakeCase = "snake_case"
+	NamingPresetPlural    = "plural"
+	NamingPresetSingular  = "singular"
+	NamingPresetIdxPrefix = "idx_prefix"
+	NamingPresetFkPrefix  = "fk_prefix"
+	NamingPresetAtSuffix  = "at_suffix"
+)
+
+// NamingPresets is the list of presets of NamingConventionPattern.
+var NamingPresets = []string{NamingPresetSnakeCase, NamingPresetPlural, NamingPresetSingular, NamingPresetIdxPrefix, NamingPresetFkPrefix, NamingPresetAtSuffix}
+
+var (
+	snakeCaseRe   = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	timestampType = regexp.MustCompile(`(?i)^(timestamp|datetime|date)`)
+)
+
+// NamingConvention checks if the names of objects match the patterns.
+type NamingConvention struct {
+	Enabled     bool                      `yaml:"enabled"`
+	Severity    Severity                  `yaml:"severity,omitempty"`
+	Tables      []NamingConventionPattern `yaml:"tables,omitempty"`
+	Views       []NamingConventionPattern `yaml:"views,omitempty"`
+	Columns     []NamingConventionPattern `yaml:"columns,omitempty"`
+	Indexes     []NamingConventionPattern `yaml:"indexes,omitempty"`
+	Constraints []NamingConventionPattern `yaml:"constraints,omitempty"`
+	Triggers    []NamingConventionPattern `yaml:"triggers,omitempty"`
+	Functions   []NamingConventionPattern `yaml:"functions,omitempty"`
+}
+
+// NamingConventionPattern is the preset or the regular expression of names.
+type NamingConventionPattern struct {
+	Preset  string   `yaml:"preset,omitempty"`
+	Pattern string   `yaml:"pattern,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// namingTarget is the object checked by NamingConvention.
+type namingTarget struct {
+	kind string
+	name string
+	warn RuleWarn
+	// applies is whether the object is the target of the presets that depend on the type of the object
+	// ( `at_suffix` for timestamp columns, `idx_prefix` for indexes not for constraints, `fk_prefix` for foreign keys ).
+	applies map[string]bool
+}
+
+// IsEnabled return Rule is enabled or not.
+func (r NamingConvention) IsEnabled() bool {
+	return r.Enabled
+}
+
+// Check if the names of objects match the patterns.
+func (r NamingConvention) Check(s *schema.Schema, exclude []string) []RuleWarn {
+	warns := []RuleWarn{}
+	if !r.IsEnabled() {
+		return warns
+	}
+
+	for _, t := range s.Tables {
+		if match(exclude, t.Name) {
+			continue
+		}
+		kind := "table"
+		patterns := r.Tables
+		if strings.Contains(strings.ToUpper(t.Type), "VIEW") {
+			kind = "view"
+			patterns = r.Views
+		}
+		warns = append(warns, checkNaming(s, patterns, namingTarget{
+			kind: kind,
+			name: t.Name,
+			warn: RuleWarn{Target: t.Name, Table: t.Name},
+		})...)
+		for _, c := range t.Columns {
+			target := fmt.Sprintf("%s.%s", t.Name, c.Name)
+			warns = append(warns, checkNaming(s, r.Columns, namingTarget{
+				kind:    "column",
+				name:    c.Name,
+				warn:    RuleWarn{Target: target, Table: t.Name, Column: c.Name},
+				applies: map[string]bool{NamingPresetAtSuffix: timestampType.MatchString(c.Type)},
+			})...)
+		}
+		for _, i := range t.Indexes {
+			target := fmt.Sprintf("%s.%s", t.Name, i.Name)
+			// Indexes for constraints are named by the constraints.
+			_, err := t.FindConstraintByName(i.Name)
+			warns = append(warns, checkNaming(s, r.Indexes, namingTarget{
+				kind:    "index",
+				name:    i.Name,
+				warn:    RuleWarn{Target: target, Table: t.Name, Index: i.Name},
+				applies: map[string]bool{NamingPresetIdxPrefix: err != nil},
+			})...)
+		}
+		for _, c := range t.Constraints {
+			target := fmt.Sprintf("%s.%s", t.Name, c.Name)
+			warns = append(warns, checkNaming(s, r.Constraints, namingTarget{
+				kind:    "constraint",
+				name:    c.Name,
+				warn:    RuleWarn{Target: target, Table: t.Name, Constraint: c.Name},
+				applies: map[string]bool{NamingPresetFkPrefix: c.Type == schema.TypeFK},
+			})...)
+		}
+		for _, trig := range t.Triggers {
+			target := fmt.Sprintf("%s.%s", t.Name, trig.Name)
+			warns = append(warns, checkNaming(s, r.Triggers, namingTarget{
+				kind: "trigger",
+				name: trig.Name,
+				warn: RuleWarn{Target: target, Table: t.Name, Trigger: trig.Name},
+			})...)
+		}
+	}
+	for _, f := range s.Functions {
+		warns = append(warns, checkNaming(s, r.Functions, namingTarget{
+			kind: "function",
+			name: f.Name,
+			warn: RuleWarn{Target: f.Name},
+		})...)
+	}
+
+	return warns
+}
+
+func checkNaming(s *schema.Schema, patterns []NamingConventionPattern, t namingTarget) []RuleWarn {
+	warns := []RuleWarn{}
+	// Names are checked without the schema name.
+	name := t.name[strings.LastIndex(t.name, ".")+1:]
+	for _, p := range patterns {
+		exclude := lo.Union(p.Exclude, s.NormalizeTableNames(slices.Clone(p.Exclude)))
+		if match(exclude, t.name) || match(exclude, t.warn.Target) {
+			continue
+		}
+		if p.Pattern != "" {
+			re, err := regexp.Compile(p.Pattern)
+			if err != nil || re.MatchString(name) {
+				continue
+			}
+			w := t.warn
+			w.Message = fmt.Sprintf("%s name `%s` does not match `%s`.", t.kind, name, p.Pattern)
+			warns = append(warns, w)
+			continue
+		}
+		if applies, ok := t.applies[p.Preset]; ok && !applies {
+			continue
+		}
+		if !matchNamingPreset(p.Preset, name) {
+			w := t.warn
+			w.Message = fmt.Sprintf("%s name `%s` is not %s.", t.kind, name, p.Preset)
+			warns = append(warns, w)
+		}
+	}
+	return warns
+}
+
+func matchNamingPreset(preset, name string) bool {
+	switch preset {
+	case NamingPresetSnakeCase:
+		return snakeCaseRe.MatchString(name)
+	case NamingPresetPlural:
+		return pluralizeClient.IsPlural(lastWord(name))
+	case NamingPresetSingular:
+		return pluralizeClient.IsSingular(lastWord(name))
+	case NamingPresetIdxPrefix:
+		return strings.HasPrefix(name, "idx_")
+	case NamingPresetFkPrefix:
+		return strings.HasPrefix(name, "fk_")
+	case NamingPresetAtSuffix:
+		return strings.HasSuffix(name, "_at")
+	}
+	return true
+}
+
+func lastWord(name string) string {
+	return name[strings.LastIndexAny(name, "_-")+1:]
+}
+
+func (r NamingConvention) validate() error {
+	for _, kp := range []lo.Tuple2[string, []NamingConventionPattern]{
+		lo.T2("tables", r.Tables),
+		lo.T2("views", r.Views),
+		lo.T2("columns", r.Columns),
+		lo.T2("indexes", r.Indexes),
+		lo.T2("constraints", r.Constraints),
+		lo.T2("triggers", r.Triggers),
+		lo.T2("functions", r.Functions),
+	} {
+		k, patterns := kp.Unpack()
+		for i, p := range patterns {
+			switch {
+			case p.Preset == "" && p.Pattern == "":
+				return fmt.Errorf("lint.namingConvention.%s[%d] preset or pattern is required", k, i)
+			case p.Preset != "" && p.Pattern != "":
+				return fmt.Errorf("lint.namingConvention.%s[%d] preset and pattern are exclusive", k, i)
+			case p.Preset != "" && !lo.Contains(NamingPresets, p.Preset):
+				return fmt.Errorf("lint.namingConvention.%s[%d] has unknown preset '%s'", k, i, p.Preset)
+			}
+			if _, err := regexp.Compile(p.Pattern); err != nil {
+				return fmt.Errorf("lint.namingConvention.%s[%d] has invalid pattern: %w", k, i, err)
+			}
+		}
+	}
+	return nil
+}
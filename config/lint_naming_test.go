@@ -0,0 +1,175 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/schema"
+)
+
+func TestNamingConvention(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        NamingConvention
+		lintExclude []string
+		want        []string
+	}{
+		{
+			"disabled",
+			NamingConvention{Enabled: false, Tables: []NamingConventionPattern{{Preset: "snake_case"}}},
+			[]string{},
+			[]string{},
+		},
+		{
+			"snake_case tables",
+			NamingConvention{Enabled: true, Tables: []NamingConventionPattern{{Preset: "snake_case"}}},
+			[]string{},
+			[]string{"UserOptions: table name `UserOptions` is not snake_case."},
+		},
+		{
+			"plural tables",
+			NamingConvention{Enabled: true, Tables: []NamingConventionPattern{{Preset: "plural"}}},
+			[]string{},
+			[]string{"post_comment: table name `post_comment` is not plural."},
+		},
+		{
+			"exclude",
+			NamingConvention{Enabled: true, Tables: []NamingConventionPattern{{Preset: "plural", Exclude: []string{"post_*"}}}},
+			[]string{"UserOptions"},
+			[]string{},
+		},
+		{
+			"views",
+			NamingConvention{Enabled: true, Views: []NamingConventionPattern{{Pattern: "^v_"}}},
+			[]string{},
+			[]string{"user_posts: view name `user_posts` does not match `^v_`."},
+		},
+		{
+			"at_suffix columns",
+			NamingConvention{Enabled: true, Columns: []NamingConventionPattern{{Preset: "at_suffix"}}},
+			[]string{},
+			[]string{"users.created: column name `created` is not at_suffix."},
+		},
+		{
+			"exclude columns",
+			NamingConvention{Enabled: true, Columns: []NamingConventionPattern{{Preset: "snake_case", Exclude: []string{"userId"}}}},
+			[]string{},
+			[]string{},
+		},
+		{
+			"idx_prefix indexes",
+			NamingConvention{Enabled: true, Indexes: []NamingConventionPattern{{Preset: "idx_prefix"}}},
+			[]string{},
+			[]string{"users.users_email_key: index name `users_email_key` is not idx_prefix."},
+		},
+		{
+			"fk_prefix constraints",
+			NamingConvention{Enabled: true, Constraints: []NamingConventionPattern{{Preset: "fk_prefix"}}},
+			[]string{},
+			[]string{"post_comment.post_comment_user_id_fkey: constraint name `post_comment_user_id_fkey` is not fk_prefix."},
+		},
+		{
+			"triggers and functions",
+			NamingConvention{Enabled: true, Triggers: []NamingConventionPattern{{Pattern: "^trg_"}}, Functions: []NamingConventionPattern{{Preset: "snake_case"}}},
+			[]string{},
+			[]string{"users.update_users: trigger name `update_users` does not match `^trg_`.", "UpdatedAt: function name `UpdatedAt` is not snake_case."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestNamingSchema(t)
+			got := []string{}
+			for _, w := range tt.rule.Check(s, tt.lintExclude) {
+				got = append(got, w.Target+": "+w.Message)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestNamingConventionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern NamingConventionPattern
+		wantErr bool
+	}{
+		{"preset", NamingConventionPattern{Preset: "snake_case"}, false},
+		{"pattern", NamingConventionPattern{Pattern: "^[a-z]+$"}, false},
+		{"empty", NamingConventionPattern{}, true},
+		{"both", NamingConventionPattern{Preset: "snake_case", Pattern: "^[a-z]+$"}, true},
+		{"unknown preset", NamingConventionPattern{Preset: "camelCase"}, true},
+		{"invalid pattern", NamingConventionPattern{Pattern: "["}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NamingConvention{Enabled: true, Columns: []NamingConventionPattern{tt.pattern}}
+			if err := r.validate(); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got error: %s", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+		})
+	}
+}
+
+func newTestNamingSchema(_ *testing.T) *schema.Schema {
+	users := &schema.Table{
+		Name: "users",
+		Type: "BASE TABLE",
+		Columns: []*schema.Column{
+			{Name: "id", Type: "bigint"},
+			{Name: "email", Type: "varchar(255)"},
+			{Name: "created", Type: "timestamp without time zone"},
+			{Name: "updated_at", Type: "timestamp without time zone"},
+		},
+		Triggers: []*schema.Trigger{
+			{Name: "update_users"},
+		},
+	}
+	users.Indexes = []*schema.Index{
+		{Name: "users_pkey", Table: &users.Name, Columns: []string{"id"}},
+		{Name: "users_email_key", Table: &users.Name, Columns: []string{"email"}},
+		{Name: "idx_users_created", Table: &users.Name, Columns: []string{"created"}},
+	}
+	users.Constraints = []*schema.Constraint{
+		{Name: "users_pkey", Type: "PRIMARY KEY", Table: &users.Name, Columns: []string{"id"}},
+	}
+	options := &schema.Table{
+		Name: "UserOptions",
+		Type: "BASE TABLE",
+		Columns: []*schema.Column{
+			{Name: "userId", Type: "bigint"},
+		},
+	}
+	comment := &schema.Table{
+		Name: "post_comment",
+		Type: "BASE TABLE",
+		Columns: []*schema.Column{
+			{Name: "user_id", Type: "bigint"},
+		},
+	}
+	comment.Constraints = []*schema.Constraint{
+		{Name: "post_comment_user_id_fkey", Type: schema.TypeFK, Table: &comment.Name, Columns: []string{"user_id"}},
+		{Name: "fk_post_comment_users", Type: schema.TypeFK, Table: &comment.Name, Columns: []string{"user_id"}},
+	}
+	view := &schema.Table{
+		Name: "user_posts",
+		Type: "VIEW",
+		Columns: []*schema.Column{
+			{Name: "user_id", Type: "bigint"},
+		},
+	}
+	return &schema.Schema{
+		Name:   "testschema",
+		Tables: []*schema.Table{users, options, comment, view},
+		Functions: []*schema.Function{
+			{Name: "UpdatedAt"},
+		},
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
 )
 
 func TestRequireTableComment(t *testing.T) {
@@ -492,6 +494,103 @@ func TestCheckLabelStyleBigQuery(t *testing.T) {
 	}
 }
 
+func TestLintCheck(t *testing.T) {
+	l := Lint{
+		RequireTableComment:  RequireTableComment{Enabled: true},
+		RequireColumnComment: RequireColumnComment{Enabled: true, Severity: SeverityWarning},
+	}
+	s := newTestSchema(t)
+	got, err := l.Check(s, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RuleWarn{
+		{Rule: "requireTableComment", Severity: SeverityError, Target: "table_a", Table: "table_a", Message: "table comment required."},
+		{Rule: "requireColumnComment", Severity: SeverityWarning, Target: "table_b.column_b1", Table: "table_b", Column: "column_b1", Message: "column comment required."},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+	if !HasError(got) {
+		t.Error("want error")
+	}
+}
+
+func TestLintCheckLintIgnore(t *testing.T) {
+	tests := []struct {
+		name         string
+		tableIgnore  []string
+		columnIgnore []string
+		otherIgnore  []string
+		want         []string
+	}{
+		{"no markers", nil, nil, nil, []string{"requireTableComment", "requireColumnComment", "unrelatedTable", "table-b-columns"}},
+		{"table", []string{"requireColumnComment"}, nil, nil, []string{"requireTableComment", "unrelatedTable", "table-b-columns"}},
+		{"column", nil, []string{"requireColumnComment"}, nil, []string{"requireTableComment", "unrelatedTable", "table-b-columns"}},
+		{"wildcard", []string{"*"}, nil, nil, []string{"requireTableComment", "unrelatedTable"}},
+		{"custom rule", nil, []string{"table-b-*"}, nil, []string{"requireTableComment", "requireColumnComment", "unrelatedTable"}},
+		{"unrelatedTable", nil, nil, []string{"unrelatedTable"}, []string{"requireTableComment", "requireColumnComment", "table-b-columns"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Lint{
+				RequireTableComment:  RequireTableComment{Enabled: true},
+				RequireColumnComment: RequireColumnComment{Enabled: true},
+				UnrelatedTable:       UnrelatedTable{Enabled: true},
+				Custom: CustomRules{
+					{Name: "table-b-columns", Target: "column", Expr: "table.Name == 'table_b' && column.Name == 'column_b1'"},
+				},
+			}
+			s := newTestSchema(t)
+			tb, _ := s.FindTableByName("table_b")
+			tb.LintIgnore = tt.tableIgnore
+			tb.Columns[0].LintIgnore = tt.columnIgnore
+			for _, tbl := range s.Tables {
+				if tbl.Name != "table_a" && tbl.Name != "table_b" {
+					tbl.LintIgnore = tt.otherIgnore
+				}
+			}
+			warns, err := l.Check(s, []string{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := lo.Uniq(lo.Map(warns, func(w RuleWarn, _ int) string { return w.Rule }))
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestLintValidate(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		wantErr  bool
+	}{
+		{"", false},
+		{SeverityError, false},
+		{SeverityWarning, false},
+		{SeverityInfo, false},
+		{"fatal", true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.severity), func(t *testing.T) {
+			l := Lint{
+				ColumnCount: ColumnCount{Enabled: true, Severity: tt.severity},
+			}
+			if err := l.validate(); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got error: %s", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+		})
+	}
+}
+
 func newTestSchema(_ *testing.T) *schema.Schema {
 	ca := &schema.Column{
 		Name:     "column_a1",
@@ -717,3 +816,186 @@ func TestRequireViewpoints(t *testing.T) {
 		}
 	}
 }
+
+func TestRequirePrimaryKey(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		lintExclude []string
+		exclude     []string
+		want        []string
+	}{
+		{true, []string{}, []string{}, []string{"logs"}},
+		{false, []string{}, []string{}, []string{}},
+		{true, []string{"logs"}, []string{}, []string{}},
+		{true, []string{}, []string{"log*"}, []string{}},
+	}
+	for i, tt := range tests {
+		r := RequirePrimaryKey{
+			Enabled: tt.enabled,
+			Exclude: tt.exclude,
+		}
+		s := newTestIntegritySchema(t)
+		got := lo.Map(r.Check(s, tt.lintExclude), func(w RuleWarn, _ int) string { return w.Target })
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("TestRequirePrimaryKey(%d): %s", i, diff)
+		}
+	}
+}
+
+func TestRequirePrimaryKeyDetection(t *testing.T) {
+	s := &schema.Schema{
+		Name: "testschema",
+		Tables: []*schema.Table{
+			{Name: "by_constraint", Type: "BASE TABLE", Columns: []*schema.Column{{Name: "id"}}, Constraints: []*schema.Constraint{{Name: "by_constraint_pkey", Type: "PRIMARY KEY", Columns: []string{"id"}}}},
+			{Name: "by_index", Type: "BASE TABLE", Columns: []*schema.Column{{Name: "id"}}, Indexes: []*schema.Index{{Name: "PRIMARY", Def: "PRIMARY KEY (id)", Columns: []string{"id"}}}},
+			{Name: "by_column", Type: "table", Columns: []*schema.Column{{Name: "id", PK: true}}},
+			{Name: "no_pk", Type: "table", Columns: []*schema.Column{{Name: "id"}}},
+			{Name: "foreign_logs", Type: "FOREIGN TABLE", Columns: []*schema.Column{{Name: "id"}}},
+			{Name: "user_stats", Type: "MATERIALIZED VIEW", Columns: []*schema.Column{{Name: "id"}}},
+		},
+	}
+	r := RequirePrimaryKey{Enabled: true}
+	got := lo.Map(r.Check(s, []string{}), func(w RuleWarn, _ int) string { return w.Target })
+	want := []string{"no_pk"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestForeignKeyTypeMismatch(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		lintExclude []string
+		exclude     []string
+		want        []string
+	}{
+		{true, []string{}, []string{}, []string{"foreign key column type does not match the parent column type. [posts.user_id integer -> users.id bigint]"}},
+		{false, []string{}, []string{}, []string{}},
+		{true, []string{"posts"}, []string{}, []string{}},
+		{true, []string{}, []string{"posts.user_id"}, []string{}},
+	}
+	for i, tt := range tests {
+		r := ForeignKeyTypeMismatch{
+			Enabled: tt.enabled,
+			Exclude: tt.exclude,
+		}
+		s := newTestIntegritySchema(t)
+		got := lo.Map(r.Check(s, tt.lintExclude), func(w RuleWarn, _ int) string { return w.Message })
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("TestForeignKeyTypeMismatch(%d): %s", i, diff)
+		}
+	}
+}
+
+func TestRedundantIndex(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		lintExclude []string
+		exclude     []string
+		want        []string
+	}{
+		{true, []string{}, []string{}, []string{"posts.posts_user_id_idx", "posts.posts_user_id_idx2"}},
+		{false, []string{}, []string{}, []string{}},
+		{true, []string{"posts"}, []string{}, []string{}},
+		{true, []string{}, []string{"posts_user_id_idx*"}, []string{}},
+	}
+	for i, tt := range tests {
+		r := RedundantIndex{
+			Enabled: tt.enabled,
+			Exclude: tt.exclude,
+		}
+		s := newTestIntegritySchema(t)
+		got := lo.Map(r.Check(s, tt.lintExclude), func(w RuleWarn, _ int) string { return w.Target })
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("TestRedundantIndex(%d): %s", i, diff)
+		}
+	}
+}
+
+func TestNullableForeignKey(t *testing.T) {
+	tests := []struct {
+		enabled     bool
+		lintExclude []string
+		allow       []string
+		want        []string
+	}{
+		{true, []string{}, []string{}, []string{"posts.editor_id"}},
+		{false, []string{}, []string{}, []string{}},
+		{true, []string{"posts"}, []string{}, []string{}},
+		{true, []string{}, []string{"editor_id"}, []string{}},
+		{true, []string{}, []string{"posts.editor_id"}, []string{}},
+	}
+	for i, tt := range tests {
+		r := NullableForeignKey{
+			Enabled: tt.enabled,
+			Allow:   tt.allow,
+		}
+		s := newTestIntegritySchema(t)
+		got := lo.Map(r.Check(s, tt.lintExclude), func(w RuleWarn, _ int) string { return w.Target })
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("TestNullableForeignKey(%d): %s", i, diff)
+		}
+	}
+}
+
+func newTestIntegritySchema(_ *testing.T) *schema.Schema {
+	users := &schema.Table{
+		Name: "users",
+		Type: "BASE TABLE",
+		Columns: []*schema.Column{
+			{Name: "id", Type: "bigint"},
+			{Name: "name", Type: "varchar(255)"},
+		},
+	}
+	users.Constraints = []*schema.Constraint{
+		{Name: "users_pkey", Type: "PRIMARY KEY", Table: &users.Name, Columns: []string{"id"}},
+	}
+	users.Indexes = []*schema.Index{
+		{Name: "users_pkey", Def: "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)", Table: &users.Name, Columns: []string{"id"}},
+		{Name: "users_id_name_idx", Def: "CREATE INDEX users_id_name_idx ON public.users USING btree (id, name)", Table: &users.Name, Columns: []string{"id", "name"}},
+	}
+	posts := &schema.Table{
+		Name: "posts",
+		Type: "BASE TABLE",
+		Columns: []*schema.Column{
+			{Name: "id", Type: "bigint"},
+			{Name: "user_id", Type: "integer"},
+			{Name: "editor_id", Type: "bigint", Nullable: true},
+			{Name: "created_at", Type: "timestamp"},
+		},
+	}
+	posts.Constraints = []*schema.Constraint{
+		{Name: "posts_pkey", Type: "PRIMARY KEY", Table: &posts.Name, Columns: []string{"id"}},
+		{Name: "posts_user_id_fk", Type: schema.TypeFK, Table: &posts.Name, Columns: []string{"user_id"}},
+		{Name: "posts_editor_id_fk", Type: schema.TypeFK, Table: &posts.Name, Columns: []string{"editor_id"}},
+	}
+	posts.Indexes = []*schema.Index{
+		{Name: "posts_user_id_idx", Def: "CREATE INDEX posts_user_id_idx ON public.posts USING btree (user_id)", Table: &posts.Name, Columns: []string{"user_id"}},
+		{Name: "posts_user_id_created_at_idx", Def: "CREATE INDEX posts_user_id_created_at_idx ON public.posts USING btree (user_id, created_at)", Table: &posts.Name, Columns: []string{"user_id", "created_at"}},
+		{Name: "posts_created_at_user_id_idx", Def: "CREATE INDEX posts_created_at_user_id_idx ON public.posts USING btree (created_at, user_id)", Table: &posts.Name, Columns: []string{"created_at", "user_id"}},
+		{Name: "posts_user_id_idx2", Def: "CREATE INDEX posts_user_id_idx2 ON public.posts USING btree (user_id, created_at)", Table: &posts.Name, Columns: []string{"user_id", "created_at"}},
+	}
+	logs := &schema.Table{
+		Name: "logs",
+		Type: "BASE TABLE",
+		Columns: []*schema.Column{
+			{Name: "message", Type: "text"},
+		},
+	}
+	view := &schema.Table{
+		Name: "user_posts",
+		Type: "VIEW",
+		Columns: []*schema.Column{
+			{Name: "user_id", Type: "bigint"},
+		},
+	}
+	return &schema.Schema{
+		Name:   "testschema",
+		Tables: []*schema.Table{users, posts, logs, view},
+		Relations: []*schema.Relation{
+			{Table: posts, Columns: []*schema.Column{posts.Columns[1]}, ParentTable: users, ParentColumns: []*schema.Column{users.Columns[0]}},
+			{Table: posts, Columns: []*schema.Column{posts.Columns[2]}, ParentTable: users, ParentColumns: []*schema.Column{users.Columns[0]}},
+			{Table: logs, Columns: []*schema.Column{logs.Columns[0]}, ParentTable: users, ParentColumns: []*schema.Column{users.Columns[1]}, Virtual: true},
+		},
+	}
+}
@@ -7,6 +7,7 @@ type Templates struct {
 	Dot     Dot     `yaml:"dot,omitempty"`
 	PUML    PUML    `yaml:"puml,omitempty"`
 	Mermaid Mermaid `yaml:"mermaid,omitempty"`
+	D2      D2      `yaml:"d2,omitempty"`
 }
 
 // MD holds the paths to the markdown template files.
@@ -17,6 +18,7 @@ type MD struct {
 	Viewpoint string `yaml:"viewpoint,omitempty"`
 	Function  string `yaml:"function,omitempty"`
 	Enum      string `yaml:"enum,omitempty"`
+	History   string `yaml:"history,omitempty"`
 }
 
 // Dot holds the paths to the dot template files.
@@ -39,3 +41,10 @@ type Mermaid struct {
 	Schema string `yaml:"schema,omitempty"`
 	Table  string `yaml:"table,omitempty"`
 }
+
+// D2 holds the paths to the D2 template files.
+// If populated the files are used to override the default ones.
+type D2 struct {
+	Schema string `yaml:"schema,omitempty"`
+	Table  string `yaml:"table,omitempty"`
+}
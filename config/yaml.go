@@ -37,12 +37,16 @@ func (f Format) MarshalYAML() ([]byte, error) {
 			Number                   bool `yaml:"number,omitempty"`
 			ShowOnlyFirstParagraph   bool `yaml:"showOnlyFirstParagraph,omitempty"`
 			HideColumnsWithoutValues bool `yaml:"hideColumnsWithoutValues,omitempty"`
+			History                  bool `yaml:"history,omitempty"`
+			CoverageTrend            bool `yaml:"coverageTrend,omitempty"`
 		}{
 			Adjust:                   f.Adjust,
 			Sort:                     f.Sort,
 			Number:                   f.Number,
 			ShowOnlyFirstParagraph:   f.ShowOnlyFirstParagraph,
 			HideColumnsWithoutValues: false,
+			History:                  f.History,
+			CoverageTrend:            f.CoverageTrend,
 		}
 		return yaml.Marshal(s)
 	}
@@ -56,6 +60,8 @@ func (f *Format) UnmarshalYAML(data []byte) error {
 		Number                   bool        `yaml:"number,omitempty"`
 		ShowOnlyFirstParagraph   bool        `yaml:"showOnlyFirstParagraph,omitempty"`
 		HideColumnsWithoutValues interface{} `yaml:"hideColumnsWithoutValues,omitempty"`
+		History                  bool        `yaml:"history,omitempty"`
+		CoverageTrend            bool        `yaml:"coverageTrend,omitempty"`
 	}{}
 	if err := yaml.Unmarshal(data, &s); err != nil {
 		return err
@@ -64,6 +70,8 @@ func (f *Format) UnmarshalYAML(data []byte) error {
 	f.Sort = s.Sort
 	f.Number = s.Number
 	f.ShowOnlyFirstParagraph = s.ShowOnlyFirstParagraph
+	f.History = s.History
+	f.CoverageTrend = s.CoverageTrend
 	switch v := s.HideColumnsWithoutValues.(type) {
 	case bool:
 		if v {
@@ -0,0 +1,91 @@
+package coverage
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+	"unicode"
+
+	"github.com/k1LoW/errors"
+)
+
+// BadgeLabel is the label of the coverage badge.
+const BadgeLabel = "doc coverage"
+
+const badgeTmpl = `<svg xmlns="http://www.w3.org/2000/svg" width="{{ .Width }}" height="20" role="img" aria-label="{{ .Label }}: {{ .Value }}">
+  <title>{{ .Label }}: {{ .Value }}</title>
+  <linearGradient id="s" x2="0" y2="100%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r">
+    <rect width="{{ .Width }}" height="20" rx="3" fill="#fff"/>
+  </clipPath>
+  <g clip-path="url(#r)">
+    <rect width="{{ .LabelWidth }}" height="20" fill="#555"/>
+    <rect x="{{ .LabelWidth }}" width="{{ .ValueWidth }}" height="20" fill="{{ .Color }}"/>
+    <rect width="{{ .Width }}" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" text-rendering="geometricPrecision" font-size="11">
+    <text x="{{ .LabelX }}" y="15" fill="#010101" fill-opacity=".3">{{ .Label }}</text>
+    <text x="{{ .LabelX }}" y="14">{{ .Label }}</text>
+    <text x="{{ .ValueX }}" y="15" fill="#010101" fill-opacity=".3">{{ .Value }}</text>
+    <text x="{{ .ValueX }}" y="14">{{ .Value }}</text>
+  </g>
+</svg>
+`
+
+// OutputBadge output the shields-style SVG badge of the coverage of all tables.
+func (c *Coverage) OutputBadge(wr io.Writer) error {
+	value := fmt.Sprintf("%g%%", c.Coverage)
+	lw := textWidth(BadgeLabel) + 10
+	vw := textWidth(value) + 10
+	tmpl := template.Must(template.New("badge").Parse(badgeTmpl))
+	if err := tmpl.Execute(wr, map[string]interface{}{
+		"Label":      BadgeLabel,
+		"Value":      value,
+		"Color":      badgeColor(c.Coverage),
+		"Width":      lw + vw,
+		"LabelWidth": lw,
+		"ValueWidth": vw,
+		"LabelX":     float64(lw) / 2,
+		"ValueX":     float64(lw) + float64(vw)/2,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// badgeColor return the color of the badge for the coverage.
+func badgeColor(coverage float64) string {
+	switch {
+	case coverage >= 90:
+		return "#4c1"
+	case coverage >= 80:
+		return "#97ca00"
+	case coverage >= 60:
+		return "#dfb317"
+	case coverage >= 40:
+		return "#fe7d37"
+	default:
+		return "#e05d44"
+	}
+}
+
+// textWidth return the approximate width of the text in 11px Verdana.
+func textWidth(s string) int {
+	w := 0.0
+	for _, r := range s {
+		switch {
+		case r == '.' || r == ' ' || r == 'i' || r == 'l' || r == 'j':
+			w += 3.5
+		case r == '%':
+			w += 12
+		case unicode.IsUpper(r):
+			w += 7.5
+		default:
+			w += 7
+		}
+	}
+	return int(w + .5)
+}
@@ -0,0 +1,44 @@
+package coverage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputBadge(t *testing.T) {
+	tests := []struct {
+		coverage float64
+	}{
+		{100},
+		{58.8},
+		{0},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%g", tt.coverage), func(t *testing.T) {
+			c := &Coverage{Coverage: tt.coverage}
+			got := &bytes.Buffer{}
+			if err := c.OutputBadge(got); err != nil {
+				t.Fatal(err)
+			}
+			f := fmt.Sprintf("coverage_badge_%g", tt.coverage)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), f, got.String())
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(wd), "testdata"))
+	return dir
+}
@@ -1,31 +1,72 @@
 package coverage
 
 import (
+	"fmt"
 	"math"
+	"sort"
 
-	"github.com/k1LoW/tbls/output/config"
+	"github.com/k1LoW/tbls/config"
+	oconfig "github.com/k1LoW/tbls/output/config"
 	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
 )
 
 type Coverage struct {
-	Name     string           `json:"name"`
-	Coverage float64          `json:"coverage"`
-	Tables   []*TableCoverage `json:"tables"`
-	Covered  int              `json:"-"`
-	Total    int              `json:"-"`
+	Name       string           `json:"name"`
+	Coverage   float64          `json:"coverage"`
+	Covered    int              `json:"covered"`
+	Total      int              `json:"total"`
+	Sections   *Sections        `json:"sections"`
+	Tables     []*TableCoverage `json:"tables"`
+	Labels     []*GroupCoverage `json:"labels,omitempty"`
+	Viewpoints []*GroupCoverage `json:"viewpoints,omitempty"`
 }
 
 type TableCoverage struct {
-	Name     string  `json:"name"`
-	Coverage float64 `json:"coverage"`
-	Covered  int     `json:"-"`
-	Total    int     `json:"-"`
+	Name     string    `json:"name"`
+	Coverage float64   `json:"coverage"`
+	Covered  int       `json:"covered"`
+	Total    int       `json:"total"`
+	Sections *Sections `json:"sections"`
+}
+
+// GroupCoverage is the coverage of the tables grouped by the table label or the viewpoint.
+type GroupCoverage struct {
+	Name     string    `json:"name"`
+	Coverage float64   `json:"coverage"`
+	Covered  int       `json:"covered"`
+	Total    int       `json:"total"`
+	Sections *Sections `json:"sections"`
+	Tables   []string  `json:"tables"`
+}
+
+// Sections is the covered and total counts of each kind of objects.
+type Sections struct {
+	Tables      *Count `json:"tables"`
+	Columns     *Count `json:"columns"`
+	Indexes     *Count `json:"indexes"`
+	Constraints *Count `json:"constraints"`
+	Triggers    *Count `json:"triggers"`
+}
+
+// Count is the covered and total counts.
+type Count struct {
+	Covered int `json:"covered"`
+	Total   int `json:"total"`
+}
+
+// Violation is the coverage under the threshold.
+type Violation struct {
+	Target    string
+	Coverage  float64
+	FailUnder float64
 }
 
 // Measure coverage.
 func Measure(s *schema.Schema) *Coverage {
 	cover := &Coverage{
-		Name: s.Name,
+		Name:     s.Name,
+		Sections: newSections(),
 	}
 	// schema
 	cover.Total++
@@ -35,59 +76,159 @@ func Measure(s *schema.Schema) *Coverage {
 
 	// tables
 	for _, t := range s.Tables {
-		tcover := &TableCoverage{
-			Name: t.Name,
-		}
+		tcover := measureTable(t)
 		cover.Tables = append(cover.Tables, tcover)
+		cover.Covered += tcover.Covered
+		cover.Total += tcover.Total
+		cover.Sections.add(tcover.Sections)
+	}
+	cover.Coverage = percentage(cover.Covered, cover.Total)
 
-		cover.Total++
-		tcover.Total++
-		if t.Comment != "" && t.Comment != config.NoTableComment {
-			cover.Covered++
-			tcover.Covered++
+	// labels
+	labels := map[string][]*TableCoverage{}
+	for i, t := range s.Tables {
+		for _, l := range t.Labels {
+			labels[l.Name] = append(labels[l.Name], cover.Tables[i])
 		}
+	}
+	names := lo.Keys(labels)
+	sort.Strings(names)
+	for _, n := range names {
+		cover.Labels = append(cover.Labels, measureGroup(n, labels[n]))
+	}
 
-		for _, c := range t.Columns {
-			cover.Total++
-			tcover.Total++
-			if c.Comment != "" && c.Comment != config.NoColumnComment {
-				cover.Covered++
-				tcover.Covered++
-			}
+	// viewpoints
+	for _, v := range s.Viewpoints {
+		if v.Schema == nil {
+			continue
 		}
+		tcovers := lo.Filter(cover.Tables, func(tc *TableCoverage, _ int) bool {
+			return lo.ContainsBy(v.Schema.Tables, func(t *schema.Table) bool { return t.Name == tc.Name })
+		})
+		cover.Viewpoints = append(cover.Viewpoints, measureGroup(v.Name, tcovers))
+	}
+	return cover
+}
 
-		for _, i := range t.Indexes {
-			cover.Total++
-			tcover.Total++
-			if i.Comment != "" {
-				cover.Covered++
-				tcover.Covered++
-			}
-		}
+// Check return the coverages under the thresholds.
+// failUnder is the threshold of the coverage of all tables, and the other thresholds are in `coverage:` of the config.
+func (c *Coverage) Check(failUnder float64, cfg config.Coverage) []*Violation {
+	violations := []*Violation{}
+	if failUnder == 0 {
+		failUnder = cfg.FailUnder
+	}
+	if c.Coverage < failUnder {
+		violations = append(violations, &Violation{Target: "All tables", Coverage: c.Coverage, FailUnder: failUnder})
+	}
+	for _, t := range c.Tables {
+		violations = append(violations, checkThresholds(fmt.Sprintf("table %s", t.Name), t.Name, t.Coverage, cfg.Tables)...)
+	}
+	for _, l := range c.Labels {
+		violations = append(violations, checkThresholds(fmt.Sprintf("label %s", l.Name), l.Name, l.Coverage, cfg.Labels)...)
+	}
+	for _, v := range c.Viewpoints {
+		violations = append(violations, checkThresholds(fmt.Sprintf("viewpoint %s", v.Name), v.Name, v.Coverage, cfg.Viewpoints)...)
+	}
+	return violations
+}
 
-		for _, c := range t.Constraints {
-			cover.Total++
-			tcover.Total++
-			if c.Comment != "" {
-				cover.Covered++
-				tcover.Covered++
-			}
-		}
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s: coverage %g%% is under %g%%", v.Target, v.Coverage, v.FailUnder)
+}
 
-		for _, trig := range t.Triggers {
-			cover.Total++
-			tcover.Total++
-			if trig.Comment != "" {
-				cover.Covered++
-				tcover.Covered++
-			}
+func checkThresholds(target, name string, coverage float64, thresholds []config.CoverageThreshold) []*Violation {
+	for _, th := range thresholds {
+		if !th.Match(name) {
+			continue
+		}
+		if coverage < th.FailUnder {
+			return []*Violation{{Target: target, Coverage: coverage, FailUnder: th.FailUnder}}
 		}
+		return nil
+	}
+	return nil
+}
 
-		tcover.Coverage = round(float64(tcover.Covered) / float64(tcover.Total) * 100)
+func measureTable(t *schema.Table) *TableCoverage {
+	tcover := &TableCoverage{
+		Name:     t.Name,
+		Sections: newSections(),
+	}
+	tcover.Sections.Tables.count(t.Comment != "" && t.Comment != oconfig.NoTableComment)
+	for _, c := range t.Columns {
+		tcover.Sections.Columns.count(c.Comment != "" && c.Comment != oconfig.NoColumnComment)
+	}
+	for _, i := range t.Indexes {
+		tcover.Sections.Indexes.count(i.Comment != "")
+	}
+	for _, c := range t.Constraints {
+		tcover.Sections.Constraints.count(c.Comment != "")
 	}
+	for _, trig := range t.Triggers {
+		tcover.Sections.Triggers.count(trig.Comment != "")
+	}
+	tcover.Covered, tcover.Total = tcover.Sections.sum()
+	tcover.Coverage = percentage(tcover.Covered, tcover.Total)
+	return tcover
+}
 
-	cover.Coverage = round(float64(cover.Covered) / float64(cover.Total) * 100)
-	return cover
+func measureGroup(name string, tcovers []*TableCoverage) *GroupCoverage {
+	g := &GroupCoverage{
+		Name:     name,
+		Sections: newSections(),
+		Tables:   []string{},
+	}
+	for _, tc := range tcovers {
+		g.Tables = append(g.Tables, tc.Name)
+		g.Sections.add(tc.Sections)
+	}
+	g.Covered, g.Total = g.Sections.sum()
+	g.Coverage = percentage(g.Covered, g.Total)
+	return g
+}
+
+func newSections() *Sections {
+	return &Sections{
+		Tables:      &Count{},
+		Columns:     &Count{},
+		Indexes:     &Count{},
+		Constraints: &Count{},
+		Triggers:    &Count{},
+	}
+}
+
+func (s *Sections) counts() []*Count {
+	return []*Count{s.Tables, s.Columns, s.Indexes, s.Constraints, s.Triggers}
+}
+
+func (s *Sections) add(in *Sections) {
+	for i, c := range in.counts() {
+		s.counts()[i].Covered += c.Covered
+		s.counts()[i].Total += c.Total
+	}
+}
+
+func (s *Sections) sum() (int, int) {
+	covered, total := 0, 0
+	for _, c := range s.counts() {
+		covered += c.Covered
+		total += c.Total
+	}
+	return covered, total
+}
+
+func (c *Count) count(covered bool) {
+	c.Total++
+	if covered {
+		c.Covered++
+	}
+}
+
+func percentage(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(covered) / float64(total) * 100)
 }
 
 func round(f float64) float64 {
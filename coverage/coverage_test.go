@@ -4,6 +4,9 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/k1LoW/tbls/config"
 	"github.com/k1LoW/tbls/schema"
 )
 
@@ -16,6 +19,78 @@ func TestMeasure(t *testing.T) {
 	if want := 17; got.Total != want {
 		t.Errorf("got %v want %v", got.Total, want)
 	}
+	wantSections := &Sections{
+		Tables:      &Count{Covered: 2, Total: 3},
+		Columns:     &Count{Covered: 7, Total: 8},
+		Indexes:     &Count{Covered: 0, Total: 1},
+		Constraints: &Count{Covered: 0, Total: 2},
+		Triggers:    &Count{Covered: 1, Total: 2},
+	}
+	if diff := cmp.Diff(got.Sections, wantSections); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestMeasureGroups(t *testing.T) {
+	s := newTestSchema(t)
+	s.Tables[1].Labels = schema.Labels{&schema.Label{Name: "team-b"}}
+	s.Tables[2].Labels = schema.Labels{&schema.Label{Name: "team-b"}}
+	s.Viewpoints = schema.Viewpoints{
+		&schema.Viewpoint{Name: "a and b", Schema: &schema.Schema{Tables: s.Tables[:2]}},
+	}
+	got := Measure(s)
+	want := []*GroupCoverage{
+		{Name: "bq-invalid", Coverage: 37.5, Covered: 3, Total: 8, Tables: []string{"table_a"}},
+		{Name: "team-b", Coverage: 87.5, Covered: 7, Total: 8, Tables: []string{"table_b", "table_c"}},
+	}
+	if diff := cmp.Diff(got.Labels, want, cmpopts.IgnoreFields(GroupCoverage{}, "Sections")); diff != "" {
+		t.Error(diff)
+	}
+	wantViewpoints := []*GroupCoverage{
+		{Name: "a and b", Coverage: 45.5, Covered: 5, Total: 11, Tables: []string{"table_a", "table_b"}},
+	}
+	if diff := cmp.Diff(got.Viewpoints, wantViewpoints, cmpopts.IgnoreFields(GroupCoverage{}, "Sections")); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		failUnder float64
+		cfg       config.Coverage
+		want      []string
+	}{
+		{"no thresholds", 0, config.Coverage{}, []string{}},
+		{"fail-under", 60, config.Coverage{}, []string{"All tables: coverage 58.8% is under 60%"}},
+		{"fail-under in config", 0, config.Coverage{FailUnder: 60}, []string{"All tables: coverage 58.8% is under 60%"}},
+		{"fail-under overrides config", 50, config.Coverage{FailUnder: 60}, []string{}},
+		{
+			"tables",
+			0,
+			config.Coverage{Tables: []config.CoverageThreshold{{Name: "table_a", FailUnder: 50}, {Name: "table_*", FailUnder: 90}}},
+			[]string{"table table_a: coverage 37.5% is under 50%", "table table_b: coverage 66.7% is under 90%"},
+		},
+		{
+			"labels",
+			0,
+			config.Coverage{Labels: []config.CoverageThreshold{{Name: "bq-invalid", FailUnder: 40}}},
+			[]string{"label bq-invalid: coverage 37.5% is under 40%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSchema(t)
+			s.Tables[0].Labels = schema.Labels{&schema.Label{Name: "bq-invalid"}}
+			got := []string{}
+			for _, v := range Measure(s).Check(tt.failUnder, tt.cfg) {
+				got = append(got, v.String())
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
 }
 
 func TestRound(t *testing.T) {
@@ -0,0 +1,137 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/k1LoW/errors"
+)
+
+// TrendFileName is the file name of the trend chart of the coverage in the document directory.
+const TrendFileName = "coverage-trend.svg"
+
+// History is the history of the coverage of all tables.
+type History []*Record
+
+// Record is the coverage of all tables at the time.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Coverage float64   `json:"coverage"`
+	Covered  int       `json:"covered"`
+	Total    int       `json:"total"`
+}
+
+const (
+	trendWidth  = 400
+	trendHeight = 120
+	trendLeft   = 40
+	trendRight  = 50
+	trendTop    = 10
+	trendBottom = 20
+)
+
+const trendTmpl = `<svg xmlns="http://www.w3.org/2000/svg" width="{{ .Width }}" height="{{ .Height }}" role="img" aria-label="{{ .Title }}">
+  <title>{{ .Title }}</title>
+  <rect width="{{ .Width }}" height="{{ .Height }}" fill="#fff"/>
+  <g stroke="#ddd" stroke-width="1">
+    <line x1="{{ .Left }}" y1="{{ .Top }}" x2="{{ .Right }}" y2="{{ .Top }}"/>
+    <line x1="{{ .Left }}" y1="{{ .Middle }}" x2="{{ .Right }}" y2="{{ .Middle }}"/>
+    <line x1="{{ .Left }}" y1="{{ .Bottom }}" x2="{{ .Right }}" y2="{{ .Bottom }}"/>
+  </g>
+  <g fill="#555" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="10">
+    <text x="{{ .Left }}" y="{{ .Top }}" dx="-4" dy="4" text-anchor="end">100%</text>
+    <text x="{{ .Left }}" y="{{ .Middle }}" dx="-4" dy="4" text-anchor="end">50%</text>
+    <text x="{{ .Left }}" y="{{ .Bottom }}" dx="-4" dy="4" text-anchor="end">0%</text>
+    <text x="{{ .Left }}" y="{{ .Height }}" dy="-4">{{ .From }}</text>
+    <text x="{{ .Right }}" y="{{ .Height }}" dy="-4" text-anchor="end">{{ .To }}</text>
+  </g>
+  <polyline fill="none" stroke="{{ .Color }}" stroke-width="2" points="{{ .Points }}"/>
+  <circle cx="{{ .LastX }}" cy="{{ .LastY }}" r="3" fill="{{ .Color }}"/>
+  <text x="{{ .LastX }}" y="{{ .LastY }}" dx="6" dy="4" fill="#333" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">{{ .Last }}</text>
+</svg>
+`
+
+// LoadHistory load the history file. If the file does not exist, it return an empty history.
+func LoadHistory(path string) (History, error) {
+	h := History{}
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	if err := json.Unmarshal(b, &h); err != nil {
+		return nil, fmt.Errorf("failed to load coverage history %s: %w", path, err)
+	}
+	return h, nil
+}
+
+// Append return the history with the coverage at the time.
+func (h History) Append(c *Coverage, t time.Time) History {
+	return append(h, &Record{
+		Time:     t,
+		Coverage: c.Coverage,
+		Covered:  c.Covered,
+		Total:    c.Total,
+	})
+}
+
+// Write the history file.
+func (h History) Write(path string) error {
+	b, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), append(b, '\n'), 0644); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// OutputTrend output the SVG line chart of the history.
+func (h History) OutputTrend(wr io.Writer) error {
+	if len(h) == 0 {
+		return errors.New("coverage history is empty")
+	}
+	plotWidth := float64(trendWidth - trendLeft - trendRight)
+	plotHeight := float64(trendHeight - trendTop - trendBottom)
+	points := []string{}
+	var x, y float64
+	for i, r := range h {
+		x = trendLeft
+		if len(h) > 1 {
+			x += plotWidth * float64(i) / float64(len(h)-1)
+		}
+		y = trendTop + plotHeight*(100-r.Coverage)/100
+		points = append(points, fmt.Sprintf("%g,%g", round(x), round(y)))
+	}
+	last := h[len(h)-1]
+	tmpl := template.Must(template.New("trend").Parse(trendTmpl))
+	if err := tmpl.Execute(wr, map[string]interface{}{
+		"Title":  fmt.Sprintf("%s trend: %g%%", BadgeLabel, last.Coverage),
+		"Width":  trendWidth,
+		"Height": trendHeight,
+		"Left":   trendLeft,
+		"Right":  trendWidth - trendRight,
+		"Top":    trendTop,
+		"Middle": trendTop + plotHeight/2,
+		"Bottom": trendTop + plotHeight,
+		"From":   h[0].Time.Format("2006-01-02"),
+		"To":     last.Time.Format("2006-01-02"),
+		"Color":  badgeColor(last.Coverage),
+		"Points": strings.Join(points, " "),
+		"LastX":  round(x),
+		"LastY":  round(y),
+		"Last":   fmt.Sprintf("%g%%", last.Coverage),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
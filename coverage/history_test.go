@@ -0,0 +1,61 @@
+package coverage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tenntenn/golden"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage-history.json")
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 0 {
+		t.Errorf("got %v\nwant empty", h)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, c := range []*Coverage{
+		{Coverage: 20, Covered: 2, Total: 10},
+		{Coverage: 45.5, Covered: 5, Total: 11},
+		{Coverage: 40, Covered: 4, Total: 10},
+		{Coverage: 83.3, Covered: 10, Total: 12},
+	} {
+		h = h.Append(c, start.AddDate(0, 0, 7*i))
+	}
+	if err := h.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, h); diff != "" {
+		t.Error(diff)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := got.OutputTrend(buf); err != nil {
+		t.Fatal(err)
+	}
+	f := "coverage_trend"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, buf.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, buf.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTrendEmpty(t *testing.T) {
+	if err := (History{}).OutputTrend(&bytes.Buffer{}); err == nil {
+		t.Error("want error")
+	}
+}
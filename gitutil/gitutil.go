@@ -0,0 +1,99 @@
+package gitutil
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cli/safeexec"
+	"github.com/k1LoW/errors"
+)
+
+// Commit is the struct for git commit.
+type Commit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// Log return the commits that modified the file in chronological order using the local `git` command.
+// Commits that deleted the file are not included.
+// The path is relative to the current working directory.
+func Log(path string) ([]*Commit, error) {
+	p, err := relPath(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := run("log", "--reverse", "--diff-filter=ACMR", "--format=%H%x1f%an%x1f%ae%x1f%aI%x1f%s", "--", "./"+p)
+	if err != nil {
+		return nil, err
+	}
+	commits := []*Commit{}
+	for _, l := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if l == "" {
+			continue
+		}
+		f := strings.SplitN(l, "\x1f", 5)
+		if len(f) != 5 {
+			return nil, fmt.Errorf("invalid git log line: %s", l)
+		}
+		d, err := time.Parse(time.RFC3339, f[3])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		commits = append(commits, &Commit{
+			Hash:    f[0],
+			Author:  f[1],
+			Email:   f[2],
+			Date:    d,
+			Subject: f[4],
+		})
+	}
+	return commits, nil
+}
+
+// Show return the content of the file at the git revision using the local `git` command.
+// The path is relative to the current working directory.
+func Show(rev, path string) ([]byte, error) {
+	p, err := relPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return run("show", fmt.Sprintf("%s:./%s", rev, p))
+}
+
+func relPath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		path, err = filepath.Rel(wd, path)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path)), nil
+}
+
+func run(args ...string) ([]byte, error) {
+	bin, err := safeexec.LookPath("git")
+	if err != nil {
+		return nil, fmt.Errorf("git command not found: %w", err)
+	}
+	c := exec.Command(bin, args...) // #nosec
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	c.Stdout = stdout
+	c.Stderr = stderr
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run 'git %s': %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
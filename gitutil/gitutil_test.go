@@ -0,0 +1,109 @@
+package gitutil
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestShow(t *testing.T) {
+	dir := newTestRepo(t)
+	p := filepath.Join(dir, "dbdoc", "schema.json")
+	commit(t, dir, p, `{"name":"first"}`)
+	commit(t, dir, p, `{"name":"second"}`)
+	t.Chdir(dir)
+
+	tests := []struct {
+		rev     string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"HEAD", "dbdoc/schema.json", `{"name":"second"}`, false},
+		{"HEAD~1", "dbdoc/schema.json", `{"name":"first"}`, false},
+		{"HEAD~1", p, `{"name":"first"}`, false},
+		{"HEAD", "dbdoc/missing.json", "", true},
+		{"unknown", "dbdoc/schema.json", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rev+":"+tt.path, func(t *testing.T) {
+			got, err := Show(tt.rev, tt.path)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("got error: %s", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %v\nwant %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	dir := newTestRepo(t)
+	p := filepath.Join(dir, "dbdoc", "schema.json")
+	commit(t, dir, p, `{"name":"first"}`)
+	commit(t, dir, filepath.Join(dir, "README.md"), "readme")
+	commit(t, dir, p, `{"name":"second"}`)
+	t.Chdir(dir)
+
+	got, err := Log("dbdoc/schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %v\nwant %v", len(got), 2)
+	}
+	for i, c := range got {
+		b, err := Show(c.Hash, "dbdoc/schema.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{`{"name":"first"}`, `{"name":"second"}`}[i]
+		if string(b) != want {
+			t.Errorf("got %v\nwant %v", string(b), want)
+		}
+		if c.Author != "tbls" || c.Email != "tbls@example.com" || c.Subject != "update schema.json" {
+			t.Errorf("unexpected commit: %#v", c)
+		}
+	}
+}
+
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command not found")
+	}
+	dir := t.TempDir()
+	git(t, dir, "init", "-q")
+	git(t, dir, "config", "user.name", "tbls")
+	git(t, dir, "config", "user.email", "tbls@example.com")
+	return dir
+}
+
+func commit(t *testing.T, dir, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", "update "+filepath.Base(path))
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	c := exec.Command("git", args...)
+	c.Dir = dir
+	if out, err := c.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %s: %s", args, err, out)
+	}
+}
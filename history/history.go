@@ -0,0 +1,100 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/k1LoW/tbls/gitutil"
+	"github.com/k1LoW/tbls/schema"
+)
+
+// History is the struct for the change history of schema.
+type History struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry is the struct for the changes of schema in a commit.
+type Entry struct {
+	Commit  string         `json:"commit"`
+	Author  string         `json:"author"`
+	Email   string         `json:"email"`
+	Date    time.Time      `json:"date"`
+	Subject string         `json:"subject"`
+	Changes schema.Changes `json:"changes"`
+}
+
+// ShortCommit return the abbreviated commit hash.
+func (e *Entry) ShortCommit() string {
+	if len(e.Commit) < 7 {
+		return e.Commit
+	}
+	return e.Commit[:7]
+}
+
+// TableHistory is the struct for the change history of a table.
+type TableHistory struct {
+	Name   string
+	Events []*Event
+}
+
+// Event is the struct for a change of a table in a commit.
+type Event struct {
+	Entry  *Entry
+	Change *schema.Change
+}
+
+// Build return History of schemas committed by the commits.
+// The commits are in chronological order and entries are in reverse chronological order.
+// Commits that do not change schema objects are skipped.
+func Build(commits []*gitutil.Commit, load func(c *gitutil.Commit) (*schema.Schema, error), hints ...*schema.RenameHint) (*History, error) {
+	h := &History{
+		Entries: []*Entry{},
+	}
+	prev := &schema.Schema{}
+	for _, c := range commits {
+		s, err := load(c)
+		if err != nil {
+			return nil, err
+		}
+		changes := schema.Diff(prev, s, hints...)
+		prev = s
+		if len(changes) == 0 {
+			continue
+		}
+		h.Entries = append([]*Entry{{
+			Commit:  c.Hash,
+			Author:  c.Author,
+			Email:   c.Email,
+			Date:    c.Date,
+			Subject: c.Subject,
+			Changes: changes,
+		}}, h.Entries...)
+	}
+	return h, nil
+}
+
+// Tables return the change histories of tables in order of table name.
+func (h *History) Tables() []*TableHistory {
+	tables := map[string]*TableHistory{}
+	names := []string{}
+	for _, e := range h.Entries {
+		for _, c := range e.Changes {
+			if c.Table == "" || c.Object == schema.ChangeObjectRelation {
+				continue
+			}
+			th, ok := tables[c.Table]
+			if !ok {
+				th = &TableHistory{Name: c.Table}
+				tables[c.Table] = th
+				names = append(names, c.Table)
+			}
+			th.Events = append(th.Events, &Event{Entry: e, Change: c})
+		}
+	}
+	sort.Strings(names)
+	ths := []*TableHistory{}
+	for _, n := range names {
+		ths = append(ths, tables[n])
+	}
+	return ths
+}
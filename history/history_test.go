@@ -0,0 +1,64 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/k1LoW/tbls/gitutil"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestBuild(t *testing.T) {
+	commits := []*gitutil.Commit{
+		{Hash: "1111111111", Author: "alice", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Subject: "init"},
+		{Hash: "2222222222", Author: "bob", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Subject: "regenerate"},
+		{Hash: "3333333333", Author: "carol", Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), Subject: "add column"},
+	}
+	schemas := map[string]*schema.Schema{}
+	for _, c := range commits {
+		schemas[c.Hash] = testutil.NewSchema(t)
+	}
+	ta, err := schemas["3333333333"].FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Columns = append(ta.Columns, &schema.Column{Name: "a3", Type: "TEXT", Nullable: true})
+
+	h, err := Build(commits, func(c *gitutil.Commit) (*schema.Schema, error) {
+		return schemas[c.Hash], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(h.Entries); got != 2 {
+		t.Fatalf("got %v\nwant %v", got, 2)
+	}
+	if got := h.Entries[0].ShortCommit(); got != "3333333" {
+		t.Errorf("got %v\nwant %v", got, "3333333")
+	}
+	if got := len(h.Entries[0].Changes); got != 1 {
+		t.Errorf("got %v\nwant %v", got, 1)
+	}
+
+	tables := h.Tables()
+	want := []string{"a", "b", "view"}
+	if len(tables) != len(want) {
+		t.Fatalf("got %v\nwant %v", len(tables), len(want))
+	}
+	for i, th := range tables {
+		if th.Name != want[i] {
+			t.Errorf("got %v\nwant %v", th.Name, want[i])
+		}
+	}
+	events := tables[0].Events
+	if len(events) != 2 {
+		t.Fatalf("got %v\nwant %v", len(events), 2)
+	}
+	if events[0].Entry.Author != "carol" || events[0].Change.Name != "a3" {
+		t.Errorf("unexpected event: %#v", events[0].Change)
+	}
+	if events[1].Entry.Author != "alice" || events[1].Change.Action != schema.ChangeActionAdded {
+		t.Errorf("unexpected event: %#v", events[1].Change)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+)
+
+// TODOComment is the placeholder of comments added by `tbls lint --fix`.
+const TODOComment = "TODO"
+
+// FixComments return the comments of the config with TODO placeholders for the tables and columns reported by `requireTableComment` and `requireColumnComment`.
+func FixComments(s *schema.Schema, comments []config.AdditionalComment, warns []config.RuleWarn) ([]config.AdditionalComment, int) {
+	fixed := 0
+	find := func(table string) *config.AdditionalComment {
+		for i := range comments {
+			if s.NormalizeTableName(comments[i].Table) == s.NormalizeTableName(table) {
+				return &comments[i]
+			}
+		}
+		comments = append(comments, config.AdditionalComment{Table: table})
+		return &comments[len(comments)-1]
+	}
+	for _, w := range warns {
+		switch w.Rule {
+		case "requireTableComment":
+			a := find(w.Table)
+			if a.TableComment == "" {
+				a.TableComment = TODOComment
+				fixed++
+			}
+		case "requireColumnComment":
+			a := find(w.Table)
+			if a.ColumnComments == nil {
+				a.ColumnComments = map[string]string{}
+			}
+			if _, ok := a.ColumnComments[w.Column]; !ok {
+				a.ColumnComments[w.Column] = TODOComment
+				fixed++
+			}
+		}
+	}
+	return comments, fixed
+}
+
+// OutputFix output the config file `in` whose `comments:` has TODO placeholders for the warnings.
+// The other sections of the config file are kept as they are.
+func (c *Config) OutputFix(wr io.Writer, in []byte, s *schema.Schema, warns []config.RuleWarn) (int, error) {
+	// Read `comments:` without expanding environment variables.
+	raw := struct {
+		Comments []config.AdditionalComment `yaml:"comments"`
+	}{}
+	if err := yaml.Unmarshal(in, &raw); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	comments, fixed := FixComments(s, raw.Comments, warns)
+	if fixed == 0 {
+		_, err := wr.Write(in)
+		return 0, errors.WithStack(err)
+	}
+	b, err := yaml.Marshal(map[string]interface{}{"comments": comments})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	f, err := parser.ParseBytes(in, parser.ParseComments)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if len(f.Docs) == 0 || f.Docs[0].Body == nil {
+		_, err := wr.Write(b)
+		return fixed, errors.WithStack(err)
+	}
+	p, err := yaml.PathString("$.comments")
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if _, err := p.FilterFile(f); err == nil {
+		cb, err := yaml.Marshal(comments)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if err := p.ReplaceWithReader(f, bytes.NewReader(cb)); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	} else {
+		root, err := yaml.PathString("$")
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+		if err := root.MergeFromReader(f, bytes.NewReader(b)); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+	if _, err := io.WriteString(wr, strings.TrimRight(f.String(), "\n")+"\n"); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return fixed, nil
+}
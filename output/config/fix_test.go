@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/config"
+)
+
+func TestOutputFix(t *testing.T) {
+	warns := []config.RuleWarn{
+		{Rule: "requireTableComment", Target: "a", Table: "a"},
+		{Rule: "requireColumnComment", Target: "a.a2", Table: "a", Column: "a2"},
+		{Rule: "requireColumnComment", Target: "b.b2", Table: "b", Column: "b2"},
+		{Rule: "requireIndexComment", Target: "a.PRIMARY KEY", Table: "a", Index: "PRIMARY KEY"},
+	}
+	tests := []struct {
+		name      string
+		in        string
+		want      string
+		wantFixed int
+	}{
+		{
+			"empty",
+			``,
+			`comments:
+- table: a
+  tableComment: TODO
+  columnComments:
+    a2: TODO
+- table: b
+  columnComments:
+    b2: TODO
+`,
+			3,
+		},
+		{
+			"append comments",
+			`# database
+dsn: pg://localhost/testdb
+er:
+  format: png
+`,
+			`# database
+dsn: pg://localhost/testdb
+er:
+  format: png
+comments:
+- table: a
+  tableComment: TODO
+  columnComments:
+    a2: TODO
+- table: b
+  columnComments:
+    b2: TODO
+`,
+			3,
+		},
+		{
+			"merge comments",
+			`dsn: pg://localhost/testdb
+comments:
+  - table: b
+    tableComment: table b
+    columnComments:
+      b1: column b1
+      b2: ${B2_COMMENT}
+`,
+			`dsn: pg://localhost/testdb
+comments:
+  - table: b
+    tableComment: table b
+    columnComments:
+      b1: column b1
+      b2: ${B2_COMMENT}
+  - table: a
+    tableComment: TODO
+    columnComments:
+      a2: TODO
+`,
+			2,
+		},
+		{
+			"nothing to fix",
+			`dsn: pg://localhost/testdb
+comments:
+  - table: a
+    tableComment: TODO
+    columnComments:
+      a2: TODO
+  - table: b
+    columnComments:
+      b2: TODO
+`,
+			`dsn: pg://localhost/testdb
+comments:
+  - table: a
+    tableComment: TODO
+    columnComments:
+      a2: TODO
+  - table: b
+    columnComments:
+      b2: TODO
+`,
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSchema(t)
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			fixed, err := New(c).OutputFix(got, []byte(tt.in), s, warns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fixed != tt.wantFixed {
+				t.Errorf("got %v\nwant %v", fixed, tt.wantFixed)
+			}
+			if diff := cmp.Diff(got.String(), tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
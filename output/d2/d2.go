@@ -0,0 +1,271 @@
+package d2
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+//go:embed templates/*
+var tmpl embed.FS
+
+var _ output.Output = &D2{}
+
+// D2 struct.
+type D2 struct {
+	config *config.Config
+	tmpl   embed.FS
+}
+
+// Group is the container of the tables in the viewpoint group.
+type Group struct {
+	Name   string
+	Desc   string
+	Tables []*schema.Table
+}
+
+// New return D2.
+func New(c *config.Config) *D2 {
+	return &D2{
+		config: c,
+		tmpl:   tmpl,
+	}
+}
+
+func (d *D2) schemaTemplate() (string, error) {
+	if len(d.config.Templates.D2.Schema) > 0 {
+		tb, err := os.ReadFile(d.config.Templates.D2.Schema)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return string(tb), nil
+	}
+	tb, err := d.tmpl.ReadFile("templates/schema.d2.tmpl")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(tb), nil
+}
+
+func (d *D2) tableTemplate() (string, error) {
+	if len(d.config.Templates.D2.Table) > 0 {
+		tb, err := os.ReadFile(d.config.Templates.D2.Table)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return string(tb), nil
+	}
+	tb, err := d.tmpl.ReadFile("templates/table.d2.tmpl")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(tb), nil
+}
+
+// OutputSchema output D2 format for full relation.
+func (d *D2) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	ts, err := d.schemaTemplate()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	groups, err := groups(s)
+	if err != nil {
+		return err
+	}
+	paths := map[string]string{}
+	for _, g := range groups {
+		for _, t := range g.Tables {
+			paths[t.Name] = fmt.Sprintf("%s.%s", quote(g.Name), quote(t.Name))
+		}
+	}
+	tmpl := template.Must(template.New(s.Name).Funcs(output.Funcs(&d.config.MergedDict)).Funcs(d.funcs(paths)).Parse(ts))
+	if err := tmpl.Execute(wr, map[string]any{
+		"Schema":      s,
+		"Groups":      groups,
+		"showComment": d.config.ER.Comment,
+		"showDef":     !d.config.ER.HideDef,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// OutputTable output D2 format for table.
+func (d *D2) OutputTable(wr io.Writer, t *schema.Table) error {
+	tables, relations, err := t.CollectTablesAndRelations(*d.config.ER.Distance, true)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tables = output.AppendReferencedTables(tables)
+	ts, err := d.tableTemplate()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmpl := template.Must(template.New(t.Name).Funcs(output.Funcs(&d.config.MergedDict)).Funcs(d.funcs(map[string]string{})).Parse(ts))
+	if err := tmpl.Execute(wr, map[string]any{
+		"Table":       tables[0],
+		"Tables":      tables[1:],
+		"Relations":   relations,
+		"showComment": d.config.ER.Comment,
+		"showDef":     !d.config.ER.HideDef,
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// OutputFunction output D2 format for function (not supported).
+func (d *D2) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// D2 format does not support individual function output
+	return nil
+}
+
+func (d *D2) funcs(paths map[string]string) template.FuncMap {
+	path := func(name string) string {
+		if p, ok := paths[name]; ok {
+			return p
+		}
+		return quote(name)
+	}
+	return template.FuncMap{
+		"d2_quote": quote,
+		"d2_path":  path,
+		"d2_endpoint": func(t *schema.Table, columns []*schema.Column) string {
+			// A relation of a single column connects the columns of sql_table, otherwise the tables.
+			if len(columns) == 1 && !columns[0].HideForER {
+				return fmt.Sprintf("%s.%s", path(t.Name), quote(columns[0].Name))
+			}
+			return path(t.Name)
+		},
+		"d2_connection":  connection,
+		"d2_arrowhead":   arrowhead,
+		"d2_constraints": constraints,
+	}
+}
+
+// groups return the containers from the groups of viewpoints.
+// Since a shape can belong to only one container in D2, a table is put in the first group that includes it.
+func groups(s *schema.Schema) ([]*Group, error) {
+	groups := []*Group{}
+	grouped := map[string]struct{}{}
+	for _, v := range s.Viewpoints {
+		if v.Schema == nil {
+			continue
+		}
+		for _, g := range v.Groups {
+			tables, _, err := v.Schema.SeparateTablesThatAreIncludedOrNot(&schema.FilterOption{
+				Include:       g.Tables,
+				IncludeLabels: g.Labels,
+			})
+			if err != nil {
+				return nil, err
+			}
+			tables = lo.Filter(tables, func(t *schema.Table, _ int) bool {
+				_, ok := grouped[t.Name]
+				return !ok
+			})
+			if len(tables) == 0 {
+				continue
+			}
+			for _, t := range tables {
+				grouped[t.Name] = struct{}{}
+			}
+			groups = append(groups, &Group{Name: g.Name, Desc: g.Desc, Tables: tables})
+		}
+	}
+	return groups, nil
+}
+
+// quote return the double-quoted string of D2.
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return fmt.Sprintf(`"%s"`, r.Replace(s))
+}
+
+// connection return the connection of D2 from the child to the parent.
+// The arrowheads are drawn only at the ends whose cardinalities are known.
+func connection(r *schema.Relation) string {
+	source := arrowhead(r.Cardinality) != ""
+	target := arrowhead(r.ParentCardinality) != ""
+	switch {
+	case source && target:
+		return "<->"
+	case source:
+		return "<-"
+	case target:
+		return "->"
+	default:
+		return "--"
+	}
+}
+
+// arrowhead return the crow's foot arrowhead shape of D2 for the cardinality.
+func arrowhead(c schema.Cardinality) string {
+	switch c {
+	case schema.ZeroOrOne:
+		return "cf-one"
+	case schema.ExactlyOne:
+		return "cf-one-required"
+	case schema.ZeroOrMore:
+		return "cf-many"
+	case schema.OneOrMore:
+		return "cf-many-required"
+	default:
+		return ""
+	}
+}
+
+// constraints return the constraint of D2 sql_table for the column.
+func constraints(t *schema.Table, c *schema.Column) string {
+	cs := []string{}
+	if lo.Contains(t.PrimaryKeyColumns(), c.Name) {
+		cs = append(cs, "primary_key")
+	}
+	if c.FK || len(c.ParentRelations) > 0 {
+		cs = append(cs, "foreign_key")
+	}
+	if isUnique(t, c) {
+		cs = append(cs, "unique")
+	}
+	switch len(cs) {
+	case 0:
+		return ""
+	case 1:
+		return cs[0]
+	default:
+		return fmt.Sprintf("[%s]", strings.Join(cs, "; "))
+	}
+}
+
+// isUnique return whether the column has the unique constraint or the unique index on the column only.
+func isUnique(t *schema.Table, c *schema.Column) bool {
+	single := func(columns []string) bool {
+		return len(columns) == 1 && columns[0] == c.Name
+	}
+	for _, cs := range t.Constraints {
+		if strings.EqualFold(cs.Type, "UNIQUE") && single(cs.Columns) {
+			return true
+		}
+	}
+	for _, i := range t.Indexes {
+		def := strings.ToUpper(i.Def)
+		if strings.Contains(def, "UNIQUE") && !strings.Contains(def, "PRIMARY") && single(i.Columns) {
+			if lo.ContainsBy(t.Constraints, func(cs *schema.Constraint) bool {
+				return strings.EqualFold(cs.Type, "PRIMARY KEY") && cs.Name == i.Name
+			}) {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
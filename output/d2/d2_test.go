@@ -0,0 +1,186 @@
+package d2
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputSchema(t *testing.T) {
+	tests := []struct {
+		hideDef         bool
+		showColumnTypes *config.ShowColumnTypes
+		wantFile        string
+	}{
+		{false, nil, "d2_test_schema"},
+		{true, nil, "d2_test_schema.hidedef"},
+		{false, &config.ShowColumnTypes{Related: true}, "d2_test_schema.hide_not_related_column"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantFile, func(t *testing.T) {
+			s := testutil.NewSchema(t)
+			c, err := config.New()
+			if err != nil {
+				t.Error(err)
+			}
+			if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+				t.Error(err)
+			}
+			c.ER.HideDef = tt.hideDef
+			c.ER.ShowColumnTypes = tt.showColumnTypes
+			if err := c.ModifySchema(s); err != nil {
+				t.Error(err)
+			}
+			o := New(c)
+			got := &bytes.Buffer{}
+			if err := o.OutputSchema(got, s); err != nil {
+				t.Error(err)
+			}
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), tt.wantFile, got.String())
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), tt.wantFile, got.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOutputSchemaTemplate(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Error(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_templates_test_tbls.yml")); err != nil {
+		t.Error(err)
+	}
+	// use the templates in the testdata directory
+	c.Templates.D2.Schema = filepath.Join(testdataDir(), c.Templates.D2.Schema)
+	if err := c.MergeAdditionalData(s); err != nil {
+		t.Error(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputSchema(got, s); err != nil {
+		t.Error(err)
+	}
+	f := "d2_template_test_schema"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Error(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Error(err)
+	}
+	if err := c.MergeAdditionalData(s); err != nil {
+		t.Error(err)
+	}
+	ta := s.Tables[0]
+
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Error(err)
+	}
+	f := "d2_test_a"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTableTemplate(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Error(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_templates_test_tbls.yml")); err != nil {
+		t.Error(err)
+	}
+	// use the templates in the testdata directory
+	c.Templates.D2.Table = filepath.Join(testdataDir(), c.Templates.D2.Table)
+	if err := c.ModifySchema(s); err != nil {
+		t.Error(err)
+	}
+
+	ta := s.Tables[0]
+
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Error(err)
+	}
+	f := "d2_template_test_a"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
+
+func TestConnection(t *testing.T) {
+	tests := []struct {
+		cardinality       schema.Cardinality
+		parentCardinality schema.Cardinality
+		want              string
+	}{
+		{schema.ZeroOrMore, schema.ExactlyOne, "<->"},
+		{schema.OneOrMore, schema.UnknownCardinality, "<-"},
+		{schema.UnknownCardinality, schema.ZeroOrOne, "->"},
+		{schema.UnknownCardinality, schema.UnknownCardinality, "--"},
+	}
+	for _, tt := range tests {
+		r := &schema.Relation{Cardinality: tt.cardinality, ParentCardinality: tt.parentCardinality}
+		if got := connection(r); got != tt.want {
+			t.Errorf("got %v want %v", got, tt.want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"users", `"users"`},
+		{"public.users", `"public.users"`},
+		{`a"b\c`, `"a\"b\\c"`},
+		{"line1\nline2", `"line1\nline2"`},
+	}
+	for _, tt := range tests {
+		if got := quote(tt.in); got != tt.want {
+			t.Errorf("got %v want %v", got, tt.want)
+		}
+	}
+}
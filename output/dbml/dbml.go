@@ -0,0 +1,313 @@
+package dbml
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+var _ output.Output = &DBML{}
+
+var (
+	identRe       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	typeRe        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\([0-9, ]*\))?$`)
+	numberRe      = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	stringValueRe = regexp.MustCompile(`^'((?:[^']|'')*)'$`)
+)
+
+// DBML struct.
+type DBML struct {
+	config *config.Config
+}
+
+// New return DBML.
+func New(c *config.Config) *DBML {
+	return &DBML{
+		config: c,
+	}
+}
+
+// OutputSchema output DBML format for full relation.
+func (d *DBML) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	b := &strings.Builder{}
+	if s.Name != "" {
+		fmt.Fprintf(b, "Project %s {\n", quoteName(s.Name))
+		if s.Driver != nil && s.Driver.Name != "" {
+			fmt.Fprintf(b, "  database_type: %s\n", quoteString(s.Driver.Name))
+		}
+		if s.Desc != "" {
+			fmt.Fprintf(b, "  Note: %s\n", quoteString(s.Desc))
+		}
+		b.WriteString("}\n")
+	}
+	for _, e := range s.Enums {
+		b.WriteString("\n")
+		writeEnum(b, e)
+	}
+	for _, t := range s.Tables {
+		b.WriteString("\n")
+		writeTable(b, t)
+	}
+	if len(s.Relations) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range s.Relations {
+		writeRef(b, r)
+	}
+	groups, err := tableGroups(s)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		b.WriteString("\n")
+		writeTableGroup(b, g)
+	}
+	if _, err := io.WriteString(wr, b.String()); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// OutputTable output DBML format for table.
+func (d *DBML) OutputTable(wr io.Writer, t *schema.Table) error {
+	tables, relations, err := t.CollectTablesAndRelations(*d.config.ER.Distance, true)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	b := &strings.Builder{}
+	for i, t := range tables {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeTable(b, t)
+	}
+	if len(relations) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range relations {
+		writeRef(b, r)
+	}
+	if _, err := io.WriteString(wr, b.String()); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// OutputFunction output DBML format for function (not supported).
+func (d *DBML) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// DBML format does not support functions
+	return nil
+}
+
+type tableGroup struct {
+	name   string
+	desc   string
+	tables []*schema.Table
+}
+
+// tableGroups return TableGroups from the groups of viewpoints.
+// Since a table can belong to only one TableGroup in DBML, a table is put in the first group that includes it.
+func tableGroups(s *schema.Schema) ([]*tableGroup, error) {
+	groups := []*tableGroup{}
+	grouped := map[string]struct{}{}
+	for _, v := range s.Viewpoints {
+		if v.Schema == nil {
+			continue
+		}
+		for _, g := range v.Groups {
+			tables, _, err := v.Schema.SeparateTablesThatAreIncludedOrNot(&schema.FilterOption{
+				Include:       g.Tables,
+				IncludeLabels: g.Labels,
+			})
+			if err != nil {
+				return nil, err
+			}
+			tables = lo.Filter(tables, func(t *schema.Table, _ int) bool {
+				_, ok := grouped[t.Name]
+				return !ok
+			})
+			if len(tables) == 0 {
+				continue
+			}
+			for _, t := range tables {
+				grouped[t.Name] = struct{}{}
+			}
+			groups = append(groups, &tableGroup{name: g.Name, desc: g.Desc, tables: tables})
+		}
+	}
+	return groups, nil
+}
+
+func writeEnum(b *strings.Builder, e *schema.Enum) {
+	fmt.Fprintf(b, "Enum %s {\n", quoteTableName(e.Name))
+	for _, v := range e.Values {
+		fmt.Fprintf(b, "  %s\n", quoteName(v))
+	}
+	b.WriteString("}\n")
+}
+
+func writeTable(b *strings.Builder, t *schema.Table) {
+	if t.Type != "" && !strings.EqualFold(t.Type, "BASE TABLE") && !strings.EqualFold(t.Type, "TABLE") {
+		fmt.Fprintf(b, "// %s\n", t.Type)
+	}
+	fmt.Fprintf(b, "Table %s {\n", quoteTableName(t.Name))
+	pks := t.PrimaryKeyColumns()
+	for _, c := range t.Columns {
+		settings := []string{}
+		if len(pks) == 1 && pks[0] == c.Name {
+			settings = append(settings, "pk")
+		}
+		if !c.Nullable {
+			settings = append(settings, "not null")
+		}
+		if c.Default.Valid {
+			settings = append(settings, fmt.Sprintf("default: %s", defaultValue(c.Default.String)))
+		}
+		if c.Comment != "" {
+			settings = append(settings, fmt.Sprintf("note: %s", quoteString(c.Comment)))
+		}
+		fmt.Fprintf(b, "  %s %s", quoteName(c.Name), quoteType(c.Type))
+		if len(settings) > 0 {
+			fmt.Fprintf(b, " [%s]", strings.Join(settings, ", "))
+		}
+		b.WriteString("\n")
+	}
+	indexes := []string{}
+	if len(pks) > 1 {
+		indexes = append(indexes, fmt.Sprintf("(%s) [pk]", strings.Join(lo.Map(pks, func(c string, _ int) string { return quoteName(c) }), ", ")))
+	}
+	for _, i := range t.Indexes {
+		if len(i.Columns) == 0 || isPrimaryKey(t, i) {
+			continue
+		}
+		cols := lo.Map(i.Columns, func(c string, _ int) string { return quoteName(c) })
+		def := cols[0]
+		if len(cols) > 1 {
+			def = fmt.Sprintf("(%s)", strings.Join(cols, ", "))
+		}
+		settings := []string{fmt.Sprintf("name: %s", quoteString(i.Name))}
+		if strings.Contains(strings.ToUpper(i.Def), "UNIQUE") {
+			settings = append(settings, "unique")
+		}
+		if i.Comment != "" {
+			settings = append(settings, fmt.Sprintf("note: %s", quoteString(i.Comment)))
+		}
+		indexes = append(indexes, fmt.Sprintf("%s [%s]", def, strings.Join(settings, ", ")))
+	}
+	if len(indexes) > 0 {
+		b.WriteString("\n  indexes {\n")
+		for _, i := range indexes {
+			fmt.Fprintf(b, "    %s\n", i)
+		}
+		b.WriteString("  }\n")
+	}
+	if t.Comment != "" {
+		fmt.Fprintf(b, "\n  Note: %s\n", quoteString(t.Comment))
+	}
+	b.WriteString("}\n")
+}
+
+func writeRef(b *strings.Builder, r *schema.Relation) {
+	if r.Virtual {
+		b.WriteString("// virtual\n")
+	}
+	fmt.Fprintf(b, "Ref: %s %s %s\n", refColumns(r.Table, r.Columns), refOperator(r), refColumns(r.ParentTable, r.ParentColumns))
+}
+
+func writeTableGroup(b *strings.Builder, g *tableGroup) {
+	fmt.Fprintf(b, "TableGroup %s {\n", quoteName(g.name))
+	for _, t := range g.tables {
+		fmt.Fprintf(b, "  %s\n", quoteTableName(t.Name))
+	}
+	if g.desc != "" {
+		fmt.Fprintf(b, "\n  Note: %s\n", quoteString(g.desc))
+	}
+	b.WriteString("}\n")
+}
+
+// refOperator return the relationship operator of DBML from the cardinalities of the relation.
+// `>` is many-to-one, `<` is one-to-many, `-` is one-to-one and `<>` is many-to-many.
+func refOperator(r *schema.Relation) string {
+	many := r.Cardinality != schema.ZeroOrOne && r.Cardinality != schema.ExactlyOne
+	parentMany := r.ParentCardinality == schema.ZeroOrMore || r.ParentCardinality == schema.OneOrMore
+	switch {
+	case many && parentMany:
+		return "<>"
+	case many:
+		return ">"
+	case parentMany:
+		return "<"
+	default:
+		return "-"
+	}
+}
+
+func refColumns(t *schema.Table, columns []*schema.Column) string {
+	cols := lo.Map(columns, func(c *schema.Column, _ int) string { return quoteName(c.Name) })
+	if len(cols) == 1 {
+		return fmt.Sprintf("%s.%s", quoteTableName(t.Name), cols[0])
+	}
+	return fmt.Sprintf("%s.(%s)", quoteTableName(t.Name), strings.Join(cols, ", "))
+}
+
+// isPrimaryKey return whether the index is the index of the primary key, which is rendered as the pk setting.
+func isPrimaryKey(t *schema.Table, i *schema.Index) bool {
+	if strings.Contains(strings.ToUpper(i.Def), "PRIMARY") {
+		return true
+	}
+	return lo.ContainsBy(t.Constraints, func(c *schema.Constraint) bool {
+		return strings.EqualFold(c.Type, "PRIMARY KEY") && c.Name == i.Name
+	})
+}
+
+// quoteTableName quote the table name. `schema.table` is quoted as `"schema"."table"`.
+func quoteTableName(name string) string {
+	if strings.Count(name, ".") == 1 {
+		s := strings.SplitN(name, ".", 2)
+		return fmt.Sprintf("%s.%s", quoteName(s[0]), quoteName(s[1]))
+	}
+	return quoteName(name)
+}
+
+func quoteName(name string) string {
+	if identRe.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `\"`))
+}
+
+func quoteType(typ string) string {
+	if typeRe.MatchString(typ) {
+		return typ
+	}
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(typ, `"`, `\"`))
+}
+
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	if strings.Contains(s, "\n") {
+		return fmt.Sprintf("'''%s'''", strings.ReplaceAll(s, "'''", `\'''`))
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", `\'`))
+}
+
+// defaultValue return the default value of DBML: numbers, booleans and strings as they are, and the others as expressions.
+func defaultValue(v string) string {
+	switch {
+	case numberRe.MatchString(v):
+		return v
+	case strings.EqualFold(v, "true"), strings.EqualFold(v, "false"), strings.EqualFold(v, "null"):
+		return strings.ToLower(v)
+	}
+	if m := stringValueRe.FindStringSubmatch(v); m != nil {
+		return quoteString(strings.ReplaceAll(m[1], "''", "'"))
+	}
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(v, "`", "\\`"))
+}
@@ -0,0 +1,125 @@
+package dbml
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Columns[1].Default = sql.NullString{String: "'it''s'", Valid: true}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+	f := "dbml_test_schema"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Fatal(err)
+	}
+	f := "dbml_test_a"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRefOperator(t *testing.T) {
+	tests := []struct {
+		cardinality       schema.Cardinality
+		parentCardinality schema.Cardinality
+		want              string
+	}{
+		{schema.ZeroOrMore, schema.ExactlyOne, ">"},
+		{schema.OneOrMore, schema.ZeroOrOne, ">"},
+		{schema.UnknownCardinality, schema.UnknownCardinality, ">"},
+		{schema.ZeroOrOne, schema.ExactlyOne, "-"},
+		{schema.ExactlyOne, schema.ZeroOrMore, "<"},
+		{schema.ZeroOrMore, schema.OneOrMore, "<>"},
+	}
+	for _, tt := range tests {
+		r := &schema.Relation{Cardinality: tt.cardinality, ParentCardinality: tt.parentCardinality}
+		if got := refOperator(r); got != tt.want {
+			t.Errorf("%s/%s: got %v\nwant %v", tt.cardinality, tt.parentCardinality, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"-1.5", "-1.5"},
+		{"TRUE", "true"},
+		{"NULL", "null"},
+		{"'draft'", "'draft'"},
+		{"'it''s'", `'it\'s'`},
+		{"CURRENT_TIMESTAMP", "`CURRENT_TIMESTAMP`"},
+		{"'Untitled'::character varying", "`'Untitled'::character varying`"},
+	}
+	for _, tt := range tests {
+		if got := defaultValue(tt.in); got != tt.want {
+			t.Errorf("%s: got %v\nwant %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// SupportFormats is the list of output formats for schema changes.
+var SupportFormats = []string{"json", "yaml", "markdown", "text"}
+
+// Diff struct.
+type Diff struct {
+	format string
+}
+
+// New return Diff.
+func New(format string) (*Diff, error) {
+	if !lo.Contains(SupportFormats, format) {
+		return nil, fmt.Errorf("unsupported diff format '%s'", format)
+	}
+	return &Diff{
+		format: format,
+	}, nil
+}
+
+// OutputChanges output schema changes.
+func (d *Diff) OutputChanges(wr io.Writer, changes schema.Changes) error {
+	switch d.format {
+	case "json":
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(changes); err != nil {
+			return errors.WithStack(err)
+		}
+	case "yaml":
+		encoder := yaml.NewEncoder(wr)
+		if err := encoder.Encode(changes); err != nil {
+			return errors.WithStack(err)
+		}
+	case "markdown":
+		if len(changes) == 0 {
+			return nil
+		}
+		if _, err := fmt.Fprint(wr, "| Action | Class | Object | Name | Attribute | From | To |\n| ------ | ----- | ------ | ---- | --------- | ---- | -- |\n"); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, c := range changes {
+			action := string(c.Action)
+			if c.Action == schema.ChangeActionRenamed {
+				action = fmt.Sprintf("%s (confidence: %g)", c.Action, c.Confidence)
+			}
+			if _, err := fmt.Fprintf(wr, "| %s | %s | %s | %s | %s | %s | %s |\n", action, c.Class, c.Object, escapeCell(c.Target()), c.Attribute, escapeCell(c.From), escapeCell(c.To)); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	case "text":
+		for _, c := range changes {
+			if _, err := fmt.Fprintln(wr, Text(c)); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Text return a one-line representation of the change.
+func Text(c *schema.Change) string {
+	switch c.Action {
+	case schema.ChangeActionAdded:
+		return fmt.Sprintf("+ %s %s: %s (%s)", c.Object, c.Target(), oneline(c.To), c.Class)
+	case schema.ChangeActionRemoved:
+		return fmt.Sprintf("- %s %s: %s (%s)", c.Object, c.Target(), oneline(c.From), c.Class)
+	case schema.ChangeActionRenamed:
+		return fmt.Sprintf("> %s %s: %s -> %s (%s, confidence: %g)", c.Object, c.Target(), c.From, c.To, c.Class, c.Confidence)
+	default:
+		return fmt.Sprintf("~ %s %s %s: %s -> %s (%s)", c.Object, c.Target(), c.Attribute, oneline(c.From), oneline(c.To), c.Class)
+	}
+}
+
+func oneline(text string) string {
+	r := strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+	return r.Replace(text)
+}
+
+func escapeCell(text string) string {
+	r := strings.NewReplacer("|", "\\|", "\r\n", "<br />", "\n", "<br />", "\r", "<br />")
+	return r.Replace(text)
+}
@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+func TestOutputChanges(t *testing.T) {
+	changes := schema.Changes{
+		{Action: schema.ChangeActionAdded, Class: schema.ChangeClassAdditive, Object: schema.ChangeObjectColumn, Table: "users", Name: "phone_number", To: "varchar(15)"},
+		{Action: schema.ChangeActionRemoved, Class: schema.ChangeClassBreaking, Object: schema.ChangeObjectTable, Table: "logs", Name: "logs", From: "BASE TABLE"},
+		{Action: schema.ChangeActionModified, Class: schema.ChangeClassCompatible, Object: schema.ChangeObjectColumn, Table: "users", Name: "email", Attribute: "comment", From: "a|b", To: "line1\nline2"},
+		{Action: schema.ChangeActionRenamed, Class: schema.ChangeClassBreaking, Object: schema.ChangeObjectColumn, Table: "users", Name: "name", From: "username", To: "name", Confidence: 0.85},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"text", `+ column users.phone_number: varchar(15) (additive)
+- table logs: BASE TABLE (breaking)
+~ column users.email comment: a|b -> line1 line2 (compatible)
+> column users.name: username -> name (breaking, confidence: 0.85)
+`},
+		{"markdown", `| Action | Class | Object | Name | Attribute | From | To |
+| ------ | ----- | ------ | ---- | --------- | ---- | -- |
+| added | additive | column | users.phone_number |  |  | varchar(15) |
+| removed | breaking | table | logs |  | BASE TABLE |  |
+| modified | compatible | column | users.email | comment | a\|b | line1<br />line2 |
+| renamed (confidence: 0.85) | breaking | column | users.name |  | username | name |
+`},
+		{"yaml", `- action: added
+  class: additive
+  object: column
+  table: users
+  name: phone_number
+  to: varchar(15)
+- action: removed
+  class: breaking
+  object: table
+  table: logs
+  name: logs
+  from: BASE TABLE
+- action: modified
+  class: compatible
+  object: column
+  table: users
+  name: email
+  attribute: comment
+  from: a|b
+  to: |-
+    line1
+    line2
+- action: renamed
+  class: breaking
+  object: column
+  table: users
+  name: name
+  from: username
+  to: name
+  confidence: 0.85
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			o, err := New(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := &bytes.Buffer{}
+			if err := o.OutputChanges(buf, changes); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("html"); err == nil {
+		t.Error("want error")
+	}
+}
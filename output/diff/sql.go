@@ -0,0 +1,745 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	ddl "github.com/k1LoW/tbls/output/sql"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// SupportDialects is the list of SQL dialects for migration.
+var SupportDialects = lo.Map(ddl.Dialects, func(d ddl.Dialect, _ int) string { return string(d) })
+
+// Phases of migration statements.
+// Statements are sorted by phase so that dependent objects are dropped before and created after the objects they depend on.
+const (
+	phaseRename = iota
+	phaseDropTrigger
+	phaseDropForeignKey
+	phaseDropConstraint
+	phaseDropIndex
+	phaseDropView
+	phaseEnum
+	phaseCreateTable
+	phaseColumn
+	phaseAddConstraint
+	phaseAddForeignKey
+	phaseAddIndex
+	phaseComment
+	phaseCreateView
+	phaseFunction
+	phaseCreateTrigger
+	phaseDropColumn
+	phaseDropTable
+	phaseDropFunction
+	phaseDropEnum
+)
+
+// renameConfidence is the minimum confidence of a detected rename to output it as RENAME statements.
+// Renames given by hints have confidence 1.
+const renameConfidence = 0.9
+
+// SQL struct.
+type SQL struct {
+	dialect string
+	ddl     *ddl.SQL
+	// g is the generator of the statements in the dialect for the schema being migrated to.
+	g *ddl.Generator
+}
+
+type statement struct {
+	phase int
+	sql   string
+}
+
+// NewSQL return SQL.
+func NewSQL(c *config.Config, dialect string) (*SQL, error) {
+	if !lo.Contains(SupportDialects, dialect) {
+		return nil, fmt.Errorf("unsupported SQL dialect '%s'", dialect)
+	}
+	d, err := ddl.New(c, dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &SQL{
+		dialect: dialect,
+		ddl:     d,
+	}, nil
+}
+
+// OutputMigration output the SQL statements to apply the changes that turn schema `from` into schema `to`.
+// Changes that cannot be expressed in the dialect are output as SQL comments.
+// Renamed tables and columns are renamed first, so the other statements refer to them by the new names.
+// Renames detected with low confidence are output as DROP and CREATE/ADD with a warning, so a wrong guess never keeps unrelated data under the new name.
+func (m *SQL) OutputMigration(wr io.Writer, from, to *schema.Schema, changes schema.Changes) error {
+	m.g = m.ddl.Generator(to)
+	stmts := []statement{}
+	modified := map[string]struct{}{}
+	changes, warnings := splitUncertainRenames(changes, from, to)
+	for _, w := range warnings {
+		stmts = append(stmts, statement{phaseRename, w})
+	}
+	from = applyRenames(from, changes)
+	for _, c := range changes {
+		var s []statement
+		switch {
+		case c.Action == schema.ChangeActionRenamed:
+			s = m.rename(c, to)
+		case c.Object == schema.ChangeObjectTable:
+			s = m.table(c, from, to)
+		case c.Object == schema.ChangeObjectColumn:
+			if c.Action == schema.ChangeActionModified && m.alterWholeColumn(c.Attribute) {
+				// Multiple modifications of a column are applied by redefining the column once.
+				key := c.Table + "." + c.Name
+				if _, ok := modified[key]; ok {
+					continue
+				}
+				modified[key] = struct{}{}
+			}
+			s = m.column(c, from, to)
+		case c.Object == schema.ChangeObjectIndex:
+			if hasConstraintChange(changes, c) {
+				// The index is created and dropped with the constraint.
+				continue
+			}
+			s = m.index(c, from, to)
+		case c.Object == schema.ChangeObjectConstraint:
+			s = m.constraint(c, from, to)
+		case c.Object == schema.ChangeObjectTrigger:
+			s = m.trigger(c, from, to)
+		case c.Object == schema.ChangeObjectFunction:
+			s = m.function(c, from, to)
+		case c.Object == schema.ChangeObjectEnum:
+			s = m.enum(c, from, to)
+		default:
+			// Relations are created with constraints, and viewpoints are not database objects.
+			continue
+		}
+		stmts = append(stmts, s...)
+	}
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return stmts[i].phase < stmts[j].phase
+	})
+	for _, s := range stmts {
+		if _, err := fmt.Fprintln(wr, s.sql); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (m *SQL) rename(c *schema.Change, to *schema.Schema) []statement {
+	t, err := to.FindTableByName(c.Table)
+	if err != nil {
+		return m.unsupported(c)
+	}
+	switch c.Object {
+	case schema.ChangeObjectTable:
+		switch m.dialect {
+		case "postgres":
+			object := "TABLE"
+			if isView(t) {
+				object = t.Type
+			}
+			return []statement{{phaseRename, fmt.Sprintf("ALTER %s %s RENAME TO %s;", object, m.g.TableName(c.From), m.g.Quote(unqualify(c.To)))}}
+		case "mysql":
+			return []statement{{phaseRename, fmt.Sprintf("RENAME TABLE %s TO %s;", m.g.TableName(c.From), m.g.TableName(c.To))}}
+		case "sqlite":
+			if isView(t) {
+				return m.unsupported(c)
+			}
+			return []statement{{phaseRename, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", m.g.TableName(c.From), m.g.Quote(unqualify(c.To)))}}
+		case "sqlserver":
+			return []statement{{phaseRename, fmt.Sprintf("EXEC sp_rename %s, %s;", m.g.Literal(c.From), m.g.Literal(unqualify(c.To)))}}
+		}
+	case schema.ChangeObjectColumn:
+		if isView(t) {
+			return nil
+		}
+		if m.dialect == "sqlserver" {
+			return []statement{{phaseRename, fmt.Sprintf("EXEC sp_rename %s, %s, 'COLUMN';", m.g.Literal(c.Table+"."+c.From), m.g.Literal(c.To))}}
+		}
+		return []statement{{phaseRename, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", m.g.TableName(c.Table), m.g.Quote(c.From), m.g.Quote(c.To))}}
+	}
+	return m.unsupported(c)
+}
+
+func (m *SQL) table(c *schema.Change, from, to *schema.Schema) []statement {
+	switch c.Action {
+	case schema.ChangeActionAdded:
+		t, err := to.FindTableByName(c.Table)
+		if err != nil {
+			return m.unsupported(c)
+		}
+		return m.createTable(c, t)
+	case schema.ChangeActionRemoved:
+		t, err := from.FindTableByName(c.Table)
+		if err != nil {
+			return m.unsupported(c)
+		}
+		return []statement{m.dropTable(t)}
+	}
+	ta, err := from.FindTableByName(c.Table)
+	if err != nil {
+		return m.unsupported(c)
+	}
+	tb, err := to.FindTableByName(c.Table)
+	if err != nil {
+		return m.unsupported(c)
+	}
+	switch c.Attribute {
+	case "comment":
+		return m.tableComment(c, tb)
+	case "type", "def":
+		if !isView(ta) && !isView(tb) {
+			// Changes of table definition are applied by the changes of columns, indexes and constraints.
+			return nil
+		}
+		if tb.Def == "" {
+			return m.unsupported(c)
+		}
+		drop := m.dropTable(ta)
+		drop.phase = phaseDropView
+		return []statement{drop, {phaseCreateView, terminate(tb.Def)}}
+	}
+	// labels
+	return nil
+}
+
+func (m *SQL) createTable(c *schema.Change, t *schema.Table) []statement {
+	if isView(t) {
+		if t.Def == "" {
+			return m.unsupported(c)
+		}
+		return []statement{{phaseCreateView, terminate(t.Def)}}
+	}
+	ts := m.g.Table(t)
+	stmts := []statement{}
+	stmts = append(stmts, phased(phaseCreateTable, ts.CreateTable)...)
+	stmts = append(stmts, phased(phaseAddIndex, ts.Indexes)...)
+	stmts = append(stmts, phased(phaseAddForeignKey, ts.ForeignKeys)...)
+	stmts = append(stmts, phased(phaseComment, ts.Comments)...)
+	for _, tr := range t.Triggers {
+		if tr.Def != "" {
+			stmts = append(stmts, statement{phaseCreateTrigger, terminate(tr.Def)})
+		}
+	}
+	return stmts
+}
+
+func (m *SQL) dropTable(t *schema.Table) statement {
+	switch strings.ToUpper(t.Type) {
+	case "VIEW":
+		return statement{phaseDropView, fmt.Sprintf("DROP VIEW %s;", m.g.TableName(t.Name))}
+	case "MATERIALIZED VIEW":
+		return statement{phaseDropView, fmt.Sprintf("DROP MATERIALIZED VIEW %s;", m.g.TableName(t.Name))}
+	}
+	return statement{phaseDropTable, fmt.Sprintf("DROP TABLE %s;", m.g.TableName(t.Name))}
+}
+
+func (m *SQL) tableComment(c *schema.Change, t *schema.Table) []statement {
+	switch m.dialect {
+	case "postgres":
+		object := "TABLE"
+		if isView(t) {
+			object = strings.ToUpper(t.Type)
+		}
+		return []statement{{phaseComment, fmt.Sprintf("COMMENT ON %s %s IS %s;", object, m.g.TableName(t.Name), m.commentValue(t.Comment))}}
+	case "mysql":
+		if isView(t) {
+			return m.unsupported(c)
+		}
+		return []statement{{phaseComment, fmt.Sprintf("ALTER TABLE %s COMMENT = %s;", m.g.TableName(t.Name), m.g.Literal(t.Comment))}}
+	}
+	return m.unsupported(c)
+}
+
+func (m *SQL) column(c *schema.Change, from, to *schema.Schema) []statement {
+	switch c.Action {
+	case schema.ChangeActionAdded:
+		col, t, err := findColumn(to, c.Table, c.Name)
+		if err != nil || isView(t) {
+			return nil
+		}
+		add := "ADD COLUMN"
+		if m.dialect == "sqlserver" {
+			add = "ADD"
+		}
+		def, notes := m.g.ColumnDef(t, col)
+		stmts := append(m.notes(phaseColumn, notes), statement{phaseColumn, fmt.Sprintf("ALTER TABLE %s %s %s;", m.g.TableName(t.Name), add, def)})
+		if col.Comment != "" && m.dialect == "postgres" {
+			stmts = append(stmts, statement{phaseComment, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", m.g.TableName(t.Name), m.g.Quote(col.Name), m.g.Literal(col.Comment))})
+		}
+		return stmts
+	case schema.ChangeActionRemoved:
+		if _, t, err := findColumn(from, c.Table, c.Name); err != nil || isView(t) {
+			return nil
+		}
+		return []statement{{phaseDropColumn, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", m.g.TableName(c.Table), m.g.Quote(c.Name))}}
+	}
+	col, t, err := findColumn(to, c.Table, c.Name)
+	if err != nil || isView(t) {
+		return nil
+	}
+	if c.Attribute == "labels" {
+		return nil
+	}
+	table := m.g.TableName(t.Name)
+	name := m.g.Quote(col.Name)
+	switch m.dialect {
+	case "postgres":
+		switch c.Attribute {
+		case "type":
+			return []statement{{phaseColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, name, m.g.ColumnType(col))}}
+		case "nullable":
+			action := "SET NOT NULL"
+			if col.Nullable {
+				action = "DROP NOT NULL"
+			}
+			return []statement{{phaseColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, name, action)}}
+		case "default":
+			if !col.Default.Valid {
+				return []statement{{phaseColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", table, name)}}
+			}
+			v, ok := m.g.DefaultValue(col)
+			if !ok {
+				return m.unsupported(c)
+			}
+			return []statement{{phaseColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", table, name, v)}}
+		case "comment":
+			return []statement{{phaseComment, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table, name, m.commentValue(col.Comment))}}
+		}
+	case "mysql":
+		def, notes := m.g.ColumnDef(t, col)
+		return append(m.notes(phaseColumn, notes), statement{phaseColumn, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, def)})
+	case "sqlserver":
+		switch c.Attribute {
+		case "type", "nullable":
+			null := "NOT NULL"
+			if col.Nullable {
+				null = "NULL"
+			}
+			return []statement{{phaseColumn, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s;", table, name, m.g.ColumnType(col), null)}}
+		}
+	}
+	return m.unsupported(c)
+}
+
+// alterWholeColumn return whether the modification of the attribute is applied by redefining the whole column.
+func (m *SQL) alterWholeColumn(attribute string) bool {
+	switch m.dialect {
+	case "mysql":
+		return attribute != "labels"
+	case "sqlserver":
+		return attribute == "type" || attribute == "nullable"
+	}
+	return false
+}
+
+func (m *SQL) index(c *schema.Change, from, to *schema.Schema) []statement {
+	switch c.Action {
+	case schema.ChangeActionAdded:
+		i, t, err := findIndex(to, c.Table, c.Name)
+		if err != nil {
+			return m.unsupported(c)
+		}
+		return []statement{m.createIndex(t, i)}
+	case schema.ChangeActionRemoved:
+		return []statement{m.dropIndex(c.Table, c.Name)}
+	}
+	i, t, err := findIndex(to, c.Table, c.Name)
+	if err != nil {
+		return m.unsupported(c)
+	}
+	if c.Attribute == "comment" {
+		if m.dialect != "postgres" {
+			return m.unsupported(c)
+		}
+		return []statement{{phaseComment, fmt.Sprintf("COMMENT ON INDEX %s IS %s;", m.g.Quote(i.Name), m.commentValue(i.Comment))}}
+	}
+	return []statement{m.dropIndex(c.Table, c.Name), m.createIndex(t, i)}
+}
+
+func (m *SQL) createIndex(t *schema.Table, i *schema.Index) statement {
+	return statement{phaseAddIndex, m.g.CreateIndex(t, i)}
+}
+
+func (m *SQL) dropIndex(table, name string) statement {
+	switch m.dialect {
+	case "mysql", "sqlserver":
+		return statement{phaseDropIndex, fmt.Sprintf("DROP INDEX %s ON %s;", m.g.Quote(name), m.g.TableName(table))}
+	}
+	if m.dialect == "postgres" && !strings.Contains(name, ".") && strings.Contains(table, ".") {
+		// Indexes belong to the schema of the table.
+		name = table[:strings.LastIndex(table, ".")+1] + name
+	}
+	return statement{phaseDropIndex, fmt.Sprintf("DROP INDEX %s;", m.g.TableName(name))}
+}
+
+func (m *SQL) constraint(c *schema.Change, from, to *schema.Schema) []statement {
+	if m.dialect == "sqlite" {
+		// SQLite does not support ALTER TABLE ... ADD/DROP CONSTRAINT.
+		return m.unsupported(c)
+	}
+	switch c.Action {
+	case schema.ChangeActionAdded:
+		cs, t, err := findConstraint(to, c.Table, c.Name)
+		if err != nil {
+			return m.unsupported(c)
+		}
+		if cs.Type == "TRIGGER" {
+			return nil
+		}
+		return m.addConstraint(t, cs)
+	case schema.ChangeActionRemoved:
+		cs, t, err := findConstraint(from, c.Table, c.Name)
+		if err != nil {
+			return m.unsupported(c)
+		}
+		if cs.Type == "TRIGGER" {
+			return nil
+		}
+		return []statement{m.dropConstraint(t, cs)}
+	}
+	csa, ta, err := findConstraint(from, c.Table, c.Name)
+	if err != nil {
+		return m.unsupported(c)
+	}
+	csb, tb, err := findConstraint(to, c.Table, c.Name)
+	if err != nil {
+		return m.unsupported(c)
+	}
+	if csb.Type == "TRIGGER" {
+		return nil
+	}
+	if c.Attribute == "comment" {
+		if m.dialect != "postgres" {
+			return m.unsupported(c)
+		}
+		return []statement{{phaseComment, fmt.Sprintf("COMMENT ON CONSTRAINT %s ON %s IS %s;", m.g.Quote(csb.Name), m.g.TableName(tb.Name), m.commentValue(csb.Comment))}}
+	}
+	return append([]statement{m.dropConstraint(ta, csa)}, m.addConstraint(tb, csb)...)
+}
+
+func (m *SQL) addConstraint(t *schema.Table, cs *schema.Constraint) []statement {
+	phase := phaseAddConstraint
+	if cs.Type == "FOREIGN KEY" {
+		phase = phaseAddForeignKey
+	}
+	def := m.g.ConstraintDef(t, cs)
+	if def == "" {
+		return []statement{{phase, fmt.Sprintf("-- unsupported in %s: add constraint %s.%s", m.dialect, t.Name, cs.Name)}}
+	}
+	if m.dialect == "mysql" && cs.Type == "PRIMARY KEY" {
+		return []statement{{phase, fmt.Sprintf("ALTER TABLE %s ADD %s;", m.g.TableName(t.Name), def)}}
+	}
+	return []statement{{phase, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", m.g.TableName(t.Name), m.g.Quote(cs.Name), def)}}
+}
+
+func (m *SQL) dropConstraint(t *schema.Table, cs *schema.Constraint) statement {
+	phase := phaseDropConstraint
+	if cs.Type == "FOREIGN KEY" {
+		phase = phaseDropForeignKey
+	}
+	if m.dialect == "mysql" {
+		switch cs.Type {
+		case "PRIMARY KEY":
+			return statement{phase, fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", m.g.TableName(t.Name))}
+		case "FOREIGN KEY":
+			return statement{phase, fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", m.g.TableName(t.Name), m.g.Quote(cs.Name))}
+		case "UNIQUE":
+			return statement{phase, fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", m.g.TableName(t.Name), m.g.Quote(cs.Name))}
+		case "CHECK":
+			return statement{phase, fmt.Sprintf("ALTER TABLE %s DROP CHECK %s;", m.g.TableName(t.Name), m.g.Quote(cs.Name))}
+		}
+	}
+	return statement{phase, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", m.g.TableName(t.Name), m.g.Quote(cs.Name))}
+}
+
+func (m *SQL) trigger(c *schema.Change, from, to *schema.Schema) []statement {
+	stmts := []statement{}
+	if c.Action != schema.ChangeActionAdded {
+		if m.dialect == "postgres" {
+			stmts = append(stmts, statement{phaseDropTrigger, fmt.Sprintf("DROP TRIGGER %s ON %s;", m.g.Quote(c.Name), m.g.TableName(c.Table))})
+		} else {
+			stmts = append(stmts, statement{phaseDropTrigger, fmt.Sprintf("DROP TRIGGER %s;", m.g.Quote(c.Name))})
+		}
+	}
+	if c.Action != schema.ChangeActionRemoved {
+		if c.Attribute == "comment" {
+			return m.unsupported(c)
+		}
+		t, err := to.FindTableByName(c.Table)
+		if err != nil {
+			return m.unsupported(c)
+		}
+		tr, err := t.FindTriggerByName(c.Name)
+		if err != nil || tr.Def == "" {
+			return m.unsupported(c)
+		}
+		stmts = append(stmts, statement{phaseCreateTrigger, terminate(tr.Def)})
+	}
+	return stmts
+}
+
+func (m *SQL) function(c *schema.Change, from, to *schema.Schema) []statement {
+	if m.dialect == "sqlite" {
+		return m.unsupported(c)
+	}
+	stmts := []statement{}
+	if c.Action != schema.ChangeActionAdded {
+		f, ok := findFunction(from, c.Name)
+		if !ok {
+			return m.unsupported(c)
+		}
+		stmts = append(stmts, m.dropFunction(f))
+	}
+	if c.Action != schema.ChangeActionRemoved {
+		f, ok := findFunction(to, c.Name)
+		if !ok || f.Def == "" {
+			return append(stmts, m.unsupported(c)...)
+		}
+		stmts = append(stmts, statement{phaseFunction, terminate(f.Def)})
+	}
+	return stmts
+}
+
+func (m *SQL) dropFunction(f *schema.Function) statement {
+	object := "FUNCTION"
+	if strings.Contains(strings.ToUpper(f.Type), "PROCEDURE") {
+		object = "PROCEDURE"
+	}
+	phase := phaseDropFunction
+	if m.dialect != "postgres" {
+		return statement{phase, fmt.Sprintf("DROP %s %s;", object, m.g.TableName(f.Name))}
+	}
+	return statement{phase, fmt.Sprintf("DROP %s %s(%s);", object, m.g.TableName(f.Name), f.Arguments)}
+}
+
+func (m *SQL) enum(c *schema.Change, from, to *schema.Schema) []statement {
+	if m.dialect != "postgres" {
+		// Enums of MySQL are column types, and other dialects do not have enum types.
+		return nil
+	}
+	switch c.Action {
+	case schema.ChangeActionAdded:
+		e, ok := findEnum(to, c.Name)
+		if !ok {
+			return m.unsupported(c)
+		}
+		return []statement{{phaseEnum, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", m.g.TableName(e.Name), strings.Join(lo.Map(e.Values, func(v string, _ int) string { return m.g.Literal(v) }), ", "))}}
+	case schema.ChangeActionRemoved:
+		return []statement{{phaseDropEnum, fmt.Sprintf("DROP TYPE %s;", m.g.TableName(c.Name))}}
+	}
+	ea, ok := findEnum(from, c.Name)
+	if !ok {
+		return m.unsupported(c)
+	}
+	eb, ok := findEnum(to, c.Name)
+	if !ok {
+		return m.unsupported(c)
+	}
+	if len(lo.Without(ea.Values, eb.Values...)) > 0 {
+		// PostgreSQL does not support removing values from enum types.
+		return m.unsupported(c)
+	}
+	stmts := []statement{}
+	for _, v := range lo.Without(eb.Values, ea.Values...) {
+		stmts = append(stmts, statement{phaseEnum, fmt.Sprintf("ALTER TYPE %s ADD VALUE %s;", m.g.TableName(eb.Name), m.g.Literal(v))})
+	}
+	return stmts
+}
+
+func (m *SQL) unsupported(c *schema.Change) []statement {
+	return []statement{{phaseComment, fmt.Sprintf("-- unsupported in %s: %s", m.dialect, Text(c))}}
+}
+
+// notes return the notes of the definitions that are not converted as SQL comments.
+func (m *SQL) notes(phase int, notes []string) []statement {
+	return phased(phase, lo.Map(notes, func(n string, _ int) string { return "-- " + n }))
+}
+
+func (m *SQL) commentValue(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return m.g.Literal(s)
+}
+
+// phased return the statements of the phase.
+func phased(phase int, sqls []string) []statement {
+	return lo.Map(sqls, func(sql string, _ int) statement { return statement{phase, sql} })
+}
+
+func terminate(def string) string {
+	return strings.TrimRight(strings.TrimSpace(def), ";") + ";"
+}
+
+// splitUncertainRenames return the changes whose renames with low confidence are replaced by removals and additions, and the warnings for them.
+// The other changes of the recreated tables and columns are dropped because they are created from the new definitions.
+func splitUncertainRenames(changes schema.Changes, from, to *schema.Schema) (schema.Changes, []string) {
+	uncertain := lo.Filter(changes, func(c *schema.Change, _ int) bool {
+		return c.Action == schema.ChangeActionRenamed && c.Confidence < renameConfidence
+	})
+	if len(uncertain) == 0 {
+		return changes, nil
+	}
+	recreated := func(c *schema.Change) bool {
+		return lo.ContainsBy(uncertain, func(r *schema.Change) bool {
+			if r.Object == schema.ChangeObjectTable {
+				return c.Table == r.To
+			}
+			return c.Object == schema.ChangeObjectColumn && c.Table == r.Table && c.Name == r.To
+		})
+	}
+	split := schema.Changes{}
+	warnings := []string{}
+	for _, c := range changes {
+		if !lo.Contains(uncertain, c) {
+			if !recreated(c) {
+				split = append(split, c)
+			}
+			continue
+		}
+		if c.Object == schema.ChangeObjectColumn && lo.ContainsBy(uncertain, func(r *schema.Change) bool {
+			return r.Object == schema.ChangeObjectTable && r.To == c.Table
+		}) {
+			// The column is created with the table.
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("-- warning: %s %s -> %s is detected as a rename with confidence %v, so it is dropped and created instead (the data is not kept). Add it to renames to rename it.", c.Object, renamedFrom(c), c.Target(), c.Confidence))
+		removed := &schema.Change{Action: schema.ChangeActionRemoved, Class: schema.ChangeClassBreaking, Object: c.Object, Table: c.Table, Name: c.From}
+		added := &schema.Change{Action: schema.ChangeActionAdded, Class: schema.ChangeClassAdditive, Object: c.Object, Table: c.Table, Name: c.To}
+		if c.Object == schema.ChangeObjectTable {
+			removed.Table = c.From
+			if t, err := from.FindTableByName(c.From); err == nil {
+				removed.From = t.Type
+			}
+			if t, err := to.FindTableByName(c.To); err == nil {
+				added.To = t.Type
+			}
+		} else {
+			if col, _, err := findColumn(from, c.Table, c.From); err == nil {
+				removed.From = col.Type
+			}
+			if col, _, err := findColumn(to, c.Table, c.To); err == nil {
+				added.To = col.Type
+			}
+		}
+		split = append(split, removed, added)
+	}
+	return split, warnings
+}
+
+// renamedFrom return the qualified old name of the renamed object.
+func renamedFrom(c *schema.Change) string {
+	if c.Object == schema.ChangeObjectTable {
+		return c.From
+	}
+	return fmt.Sprintf("%s.%s", c.Table, c.From)
+}
+
+// applyRenames return a copy of the schema whose renamed tables and columns have the new names.
+func applyRenames(s *schema.Schema, changes schema.Changes) *schema.Schema {
+	renamed := lo.Filter(changes, func(c *schema.Change, _ int) bool {
+		return c.Action == schema.ChangeActionRenamed
+	})
+	if len(renamed) == 0 {
+		return s
+	}
+	tableNames := map[string]string{}
+	for _, c := range renamed {
+		if c.Object == schema.ChangeObjectTable {
+			tableNames[c.From] = c.To
+		}
+	}
+	copied := *s
+	copied.Tables = make([]*schema.Table, 0, len(s.Tables))
+	for _, t := range s.Tables {
+		tc := *t
+		if to, ok := tableNames[t.Name]; ok {
+			tc.Name = to
+		}
+		tc.Columns = make([]*schema.Column, 0, len(t.Columns))
+		for _, col := range t.Columns {
+			cc := *col
+			if rc, ok := lo.Find(renamed, func(c *schema.Change) bool {
+				return c.Object == schema.ChangeObjectColumn && c.Table == tc.Name && c.From == col.Name
+			}); ok {
+				cc.Name = rc.To
+			}
+			tc.Columns = append(tc.Columns, &cc)
+		}
+		copied.Tables = append(copied.Tables, &tc)
+	}
+	return &copied
+}
+
+// unqualify return the name without the schema name.
+func unqualify(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func isView(t *schema.Table) bool {
+	return strings.Contains(strings.ToUpper(t.Type), "VIEW")
+}
+
+func hasConstraintChange(changes schema.Changes, c *schema.Change) bool {
+	return lo.ContainsBy(changes, func(cc *schema.Change) bool {
+		return cc.Object == schema.ChangeObjectConstraint && cc.Action == c.Action && cc.Table == c.Table && cc.Name == c.Name
+	})
+}
+
+func findColumn(s *schema.Schema, table, name string) (*schema.Column, *schema.Table, error) {
+	t, err := s.FindTableByName(table)
+	if err != nil {
+		return nil, nil, err
+	}
+	col, err := t.FindColumnByName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return col, t, nil
+}
+
+func findIndex(s *schema.Schema, table, name string) (*schema.Index, *schema.Table, error) {
+	t, err := s.FindTableByName(table)
+	if err != nil {
+		return nil, nil, err
+	}
+	i, err := t.FindIndexByName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return i, t, nil
+}
+
+func findConstraint(s *schema.Schema, table, name string) (*schema.Constraint, *schema.Table, error) {
+	t, err := s.FindTableByName(table)
+	if err != nil {
+		return nil, nil, err
+	}
+	cs, err := t.FindConstraintByName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cs, t, nil
+}
+
+func findFunction(s *schema.Schema, key string) (*schema.Function, bool) {
+	return lo.Find(s.Functions, func(f *schema.Function) bool {
+		return fmt.Sprintf("%s(%s)", f.Name, f.Arguments) == key
+	})
+}
+
+func findEnum(s *schema.Schema, name string) (*schema.Enum, bool) {
+	return lo.Find(s.Enums, func(e *schema.Enum) bool {
+		return e.Name == name
+	})
+}
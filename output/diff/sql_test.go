@@ -0,0 +1,172 @@
+package diff
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputMigration(t *testing.T) {
+	for _, dialect := range SupportDialects {
+		t.Run(dialect, func(t *testing.T) {
+			from := testutil.NewSchema(t)
+			to := testutil.NewSchema(t)
+			ta, err := to.FindTableByName("a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ta.Comment = "table a (modified)"
+			ta.Columns = append(ta.Columns, &schema.Column{Name: "a3", Type: "varchar(255)", Nullable: true, Default: sql.NullString{String: "'none'", Valid: true}, Comment: "column a3"})
+			ta.Indexes = append(ta.Indexes, &schema.Index{Name: "a_a2_idx", Def: "CREATE INDEX a_a2_idx ON a (a2)", Table: &ta.Name, Columns: []string{"a2"}})
+			tb, err := to.FindTableByName("b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tb.Columns[1].Type = "varchar(10)"
+			tb.Columns[1].Nullable = true
+			tb.Constraints = append(tb.Constraints, &schema.Constraint{Name: "b_b_fk", Type: "FOREIGN KEY", Def: "FOREIGN KEY (b) REFERENCES a(a)", Table: &tb.Name, ReferencedTable: &ta.Name, Columns: []string{"b"}, ReferencedColumns: []string{"a"}})
+			tv, err := to.FindTableByName("view")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tv.Def = "CREATE VIEW view AS SELECT a, b, a2 FROM a JOIN b ON a.a = b.b"
+			tc := &schema.Table{
+				Name: "c",
+				Type: "BASE TABLE",
+				Columns: []*schema.Column{
+					{Name: "c", Type: "INTEGER"},
+					{Name: "c2", Type: "TEXT", Nullable: true},
+				},
+			}
+			tc.Constraints = []*schema.Constraint{{Name: "c_pkey", Type: "PRIMARY KEY", Def: "PRIMARY KEY (c)", Table: &tc.Name, Columns: []string{"c"}}}
+			to.Tables = append(to.Tables, tc)
+			to.Enums[0].Values = append(to.Enums[0].Values, "four")
+
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := NewSQL(c, dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			if err := m.OutputMigration(got, from, to, schema.Diff(from, to)); err != nil {
+				t.Fatal(err)
+			}
+			f := fmt.Sprintf("diff_sql_%s", dialect)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), f, got)
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), f, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOutputMigrationWithRenames(t *testing.T) {
+	for _, dialect := range SupportDialects {
+		t.Run(dialect, func(t *testing.T) {
+			from := testutil.NewSchema(t)
+			to := testutil.NewSchema(t)
+			tb, err := to.FindTableByName("b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tb.Name = "b_renamed"
+			tb.Columns[1].Name = "b_two"
+			tb.Columns[1].Type = "varchar(10)"
+			hints := []*schema.RenameHint{
+				{From: "b", To: "b_renamed"},
+				{Table: "b_renamed", From: "b2", To: "b_two"},
+			}
+
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := NewSQL(c, dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			if err := m.OutputMigration(got, from, to, schema.Diff(from, to, hints...)); err != nil {
+				t.Fatal(err)
+			}
+			f := fmt.Sprintf("diff_sql_rename_%s", dialect)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), f, got)
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), f, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOutputMigrationWithDetectedRenames(t *testing.T) {
+	for _, dialect := range SupportDialects {
+		t.Run(dialect, func(t *testing.T) {
+			from := testutil.NewSchema(t)
+			to := testutil.NewSchema(t)
+			ta, err := to.FindTableByName("a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ta.Columns[1].Name = "a3"
+			tb, err := to.FindTableByName("b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tb.Name = "b_renamed"
+
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := NewSQL(c, dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			if err := m.OutputMigration(got, from, to, schema.Diff(from, to)); err != nil {
+				t.Fatal(err)
+			}
+			f := fmt.Sprintf("diff_sql_detected_rename_%s", dialect)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), f, got)
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), f, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestNewSQL(t *testing.T) {
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSQL(c, "oracle"); err == nil {
+		t.Error("want error")
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -0,0 +1,368 @@
+package drawio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/output/gviz"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+const (
+	rowHeight    = 30
+	keyWidth     = 40
+	minWidth     = 200
+	tableStyle   = "shape=table;startSize=30;container=1;collapsible=1;childLayout=tableLayout;fixedRows=1;rowLines=0;fontStyle=1;align=center;resizeLast=1;"
+	rowStyle     = "shape=tableRow;horizontal=0;startSize=0;swimlaneHead=0;swimlaneBody=0;fillColor=none;collapsible=0;dropTarget=0;points=[[0,0.5],[1,0.5]];portConstraint=eastwest;top=0;left=0;right=0;bottom=0;"
+	keyStyle     = "shape=partialRectangle;connectable=0;fillColor=none;top=0;left=0;bottom=0;right=0;fontStyle=1;overflow=hidden;"
+	columnStyle  = "shape=partialRectangle;connectable=0;fillColor=none;top=0;left=0;bottom=0;right=0;align=left;spacingLeft=6;overflow=hidden;"
+	edgeStyle    = "edgeStyle=entityRelationEdgeStyle;fontSize=12;endFill=0;startFill=0;"
+	commentStyle = "text;whiteSpace=wrap;align=left;verticalAlign=top;fontColor=#333333;"
+)
+
+var _ output.Output = &Drawio{}
+
+// Drawio struct.
+type Drawio struct {
+	config *config.Config
+	gviz   *gviz.Gviz
+}
+
+// New return Drawio.
+func New(c *config.Config) *Drawio {
+	return &Drawio{
+		config: c,
+		gviz:   gviz.New(c),
+	}
+}
+
+type mxFile struct {
+	XMLName xml.Name   `xml:"mxfile"`
+	Host    string     `xml:"host,attr"`
+	Diagram *mxDiagram `xml:"diagram"`
+}
+
+type mxDiagram struct {
+	ID    string        `xml:"id,attr"`
+	Name  string        `xml:"name,attr"`
+	Model *mxGraphModel `xml:"mxGraphModel"`
+}
+
+type mxGraphModel struct {
+	Grid    int       `xml:"grid,attr"`
+	Guides  int       `xml:"guides,attr"`
+	Connect int       `xml:"connect,attr"`
+	Arrows  int       `xml:"arrows,attr"`
+	Page    int       `xml:"page,attr"`
+	Cells   []*mxCell `xml:"root>mxCell"`
+}
+
+type mxCell struct {
+	ID       string      `xml:"id,attr"`
+	Value    *string     `xml:"value,attr"`
+	Style    string      `xml:"style,attr,omitempty"`
+	Vertex   string      `xml:"vertex,attr,omitempty"`
+	Edge     string      `xml:"edge,attr,omitempty"`
+	Parent   string      `xml:"parent,attr,omitempty"`
+	Source   string      `xml:"source,attr,omitempty"`
+	Target   string      `xml:"target,attr,omitempty"`
+	Geometry *mxGeometry `xml:"mxGeometry"`
+}
+
+type mxGeometry struct {
+	X        float64 `xml:"x,attr,omitempty"`
+	Y        float64 `xml:"y,attr,omitempty"`
+	Width    float64 `xml:"width,attr,omitempty"`
+	Height   float64 `xml:"height,attr,omitempty"`
+	Relative string  `xml:"relative,attr,omitempty"`
+	As       string  `xml:"as,attr"`
+}
+
+// diagram builds the cells of the draw.io diagram.
+type diagram struct {
+	config    *config.Config
+	positions map[string]*gviz.Position
+	cells     []*mxCell
+	tableIDs  map[string]string
+	columnIDs map[string]string
+	// nextX is the x of the next table that is not laid out by Graphviz.
+	nextX float64
+}
+
+// OutputSchema output draw.io format for full relation.
+func (d *Drawio) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	positions, err := d.gviz.LayoutSchema(s)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	dg := d.newDiagram(positions)
+	for _, t := range s.Tables {
+		dg.addTable(t)
+	}
+	for _, r := range s.Relations {
+		dg.addRelation(r)
+	}
+	for _, t := range s.Tables {
+		dg.addReferencedTables(t)
+	}
+	return dg.write(wr, s.Name)
+}
+
+// OutputTable output draw.io format for table.
+func (d *Drawio) OutputTable(wr io.Writer, t *schema.Table) error {
+	tables, relations, err := t.CollectTablesAndRelations(*d.config.ER.Distance, true)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tables = output.AppendReferencedTables(tables)
+	positions, err := d.gviz.LayoutTable(t)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	dg := d.newDiagram(positions)
+	for _, t := range tables {
+		dg.addTable(t)
+	}
+	for _, r := range relations {
+		dg.addRelation(r)
+	}
+	dg.addReferencedTables(tables[0])
+	return dg.write(wr, t.Name)
+}
+
+// OutputFunction output draw.io format for function (not supported).
+func (d *Drawio) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// draw.io format does not support individual function output
+	return nil
+}
+
+func (d *Drawio) newDiagram(positions map[string]*gviz.Position) *diagram {
+	return &diagram{
+		config:    d.config,
+		positions: positions,
+		cells: []*mxCell{
+			{ID: "0"},
+			{ID: "1", Parent: "0"},
+		},
+		tableIDs:  map[string]string{},
+		columnIDs: map[string]string{},
+	}
+}
+
+func (dg *diagram) addTable(t *schema.Table) {
+	if _, ok := dg.tableIDs[t.Name]; ok {
+		return
+	}
+	columns := lo.Filter(t.Columns, func(c *schema.Column, _ int) bool {
+		return !c.HideForER
+	})
+	width := float64(minWidth)
+	height := float64(rowHeight * (len(columns) + 1))
+	x, y := dg.nextX, dg.bottom()+rowHeight
+	if p, ok := dg.positions[t.Name]; ok {
+		// keep the center of the node laid out by Graphviz
+		width = math.Max(width, p.Width)
+		x = p.X + (p.Width-width)/2
+		y = p.Y + (p.Height-height)/2
+	} else {
+		dg.nextX += width + rowHeight
+	}
+	id := fmt.Sprintf("table-%d", len(dg.tableIDs))
+	dg.tableIDs[t.Name] = id
+	dg.cells = append(dg.cells, &mxCell{
+		ID:       id,
+		Value:    value(t.Name),
+		Style:    tableStyle,
+		Vertex:   "1",
+		Parent:   "1",
+		Geometry: &mxGeometry{X: round(x), Y: round(y), Width: round(width), Height: height, As: "geometry"},
+	})
+	pks := t.PrimaryKeyColumns()
+	for i, c := range columns {
+		rid := fmt.Sprintf("%s-column-%d", id, i)
+		dg.columnIDs[columnKey(t, c)] = rid
+		key := ""
+		switch {
+		case lo.Contains(pks, c.Name):
+			key = "PK"
+		case c.FK || len(c.ParentRelations) > 0:
+			key = "FK"
+		}
+		name := c.Name
+		if dg.config.ER.Comment && c.Comment != "" {
+			name = fmt.Sprintf("%s (%s)", c.Name, c.Comment)
+		}
+		dg.cells = append(dg.cells,
+			&mxCell{
+				ID:       rid,
+				Value:    value(""),
+				Style:    rowStyle,
+				Vertex:   "1",
+				Parent:   id,
+				Geometry: &mxGeometry{Y: float64(rowHeight * (i + 1)), Width: round(width), Height: rowHeight, As: "geometry"},
+			},
+			&mxCell{
+				ID:       rid + "-key",
+				Value:    value(key),
+				Style:    keyStyle,
+				Vertex:   "1",
+				Parent:   rid,
+				Geometry: &mxGeometry{Width: keyWidth, Height: rowHeight, As: "geometry"},
+			},
+			&mxCell{
+				ID:       rid + "-name",
+				Value:    value(fmt.Sprintf("%s %s", name, c.Type)),
+				Style:    columnStyle,
+				Vertex:   "1",
+				Parent:   rid,
+				Geometry: &mxGeometry{X: keyWidth, Width: round(width - keyWidth), Height: rowHeight, As: "geometry"},
+			},
+		)
+	}
+	if dg.config.ER.Comment && t.Comment != "" {
+		dg.cells = append(dg.cells, &mxCell{
+			ID:       id + "-comment",
+			Value:    value(t.Comment),
+			Style:    commentStyle,
+			Vertex:   "1",
+			Parent:   "1",
+			Geometry: &mxGeometry{X: round(x), Y: round(y + height), Width: round(width), Height: rowHeight, As: "geometry"},
+		})
+	}
+}
+
+func (dg *diagram) addRelation(r *schema.Relation) {
+	if r.HideForER {
+		return
+	}
+	source, ok := dg.endpoint(r.Table, r.Columns)
+	if !ok {
+		return
+	}
+	target, ok := dg.endpoint(r.ParentTable, r.ParentColumns)
+	if !ok {
+		return
+	}
+	style := fmt.Sprintf("%sstartArrow=%s;endArrow=%s;", edgeStyle, arrow(r.Cardinality), arrow(r.ParentCardinality))
+	if r.Virtual {
+		style += "dashed=1;"
+	}
+	label := ""
+	if !dg.config.ER.HideDef {
+		label = r.Def
+	}
+	dg.addEdge(source, target, label, style)
+}
+
+func (dg *diagram) addReferencedTables(t *schema.Table) {
+	source, ok := dg.tableIDs[t.Name]
+	if !ok {
+		return
+	}
+	for _, rt := range t.ReferencedTables {
+		target, ok := dg.tableIDs[rt.Name]
+		if !ok {
+			continue
+		}
+		dg.addEdge(source, target, "", edgeStyle+"startArrow=none;endArrow=none;dashed=1;")
+	}
+}
+
+func (dg *diagram) addEdge(source, target, label, style string) {
+	dg.cells = append(dg.cells, &mxCell{
+		ID:       fmt.Sprintf("edge-%d", len(dg.cells)),
+		Value:    value(label),
+		Style:    style,
+		Edge:     "1",
+		Parent:   "1",
+		Source:   source,
+		Target:   target,
+		Geometry: &mxGeometry{Relative: "1", As: "geometry"},
+	})
+}
+
+// endpoint return the row of the column for the relation of a single column, otherwise the table.
+func (dg *diagram) endpoint(t *schema.Table, columns []*schema.Column) (string, bool) {
+	if len(columns) == 1 {
+		if id, ok := dg.columnIDs[columnKey(t, columns[0])]; ok {
+			return id, true
+		}
+	}
+	id, ok := dg.tableIDs[t.Name]
+	return id, ok
+}
+
+// bottom return the bottom of the tables laid out by Graphviz.
+func (dg *diagram) bottom() float64 {
+	bottom := 0.0
+	for _, p := range dg.positions {
+		bottom = math.Max(bottom, p.Y+p.Height)
+	}
+	return bottom
+}
+
+func (dg *diagram) write(wr io.Writer, name string) error {
+	f := &mxFile{
+		Host: "tbls",
+		Diagram: &mxDiagram{
+			ID:   "tbls",
+			Name: name,
+			Model: &mxGraphModel{
+				Grid:    1,
+				Guides:  1,
+				Connect: 1,
+				Arrows:  1,
+				Page:    0,
+				Cells:   dg.cells,
+			},
+		},
+	}
+	b, err := xml.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := io.WriteString(wr, xml.Header); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := wr.Write(b); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := io.WriteString(wr, "\n"); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// arrow return the ER arrow style of draw.io for the cardinality.
+func arrow(c schema.Cardinality) string {
+	switch c {
+	case schema.ZeroOrOne:
+		return "ERzeroToOne"
+	case schema.ExactlyOne:
+		return "ERmandOne"
+	case schema.ZeroOrMore:
+		return "ERzeroToMany"
+	case schema.OneOrMore:
+		return "ERoneToMany"
+	default:
+		return "none"
+	}
+}
+
+func columnKey(t *schema.Table, c *schema.Column) string {
+	return fmt.Sprintf("%s\x00%s", t.Name, c.Name)
+}
+
+func value(v string) *string {
+	return &v
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,143 @@
+package drawio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+	cells := parse(t, got.Bytes())
+
+	for _, want := range []string{"a", "b", "view"} {
+		if findCell(cells, func(c *mxCell) bool { return c.Style == tableStyle && *c.Value == want }) == nil {
+			t.Errorf("table %s not found", want)
+		}
+	}
+	pk := findCell(cells, func(c *mxCell) bool { return c.ID == "table-0-column-0-key" })
+	if pk == nil || *pk.Value != "PK" {
+		t.Errorf("got %v want PK", pk)
+	}
+	r := findCell(cells, func(c *mxCell) bool { return c.Edge == "1" && !strings.Contains(c.Style, "dashed=1") })
+	if r == nil {
+		t.Fatal("relation not found")
+	}
+	if want := "table-1-column-0"; r.Source != want {
+		t.Errorf("got %v want %v", r.Source, want)
+	}
+	if want := "table-0-column-0"; r.Target != want {
+		t.Errorf("got %v want %v", r.Target, want)
+	}
+	if want := "startArrow=ERoneToMany;endArrow=ERmandOne;"; !strings.Contains(r.Style, want) {
+		t.Errorf("got %v want %v", r.Style, want)
+	}
+	for _, c := range cells {
+		if c.Style != tableStyle {
+			continue
+		}
+		if c.Geometry.X < 0 || c.Geometry.Y < 0 || c.Geometry.Width < minWidth {
+			t.Errorf("invalid geometry of %s: %#v", *c.Value, c.Geometry)
+		}
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Fatal(err)
+	}
+	cells := parse(t, got.Bytes())
+	tables := []string{}
+	for _, c := range cells {
+		if c.Style == tableStyle {
+			tables = append(tables, *c.Value)
+		}
+	}
+	if want := "a,b"; strings.Join(tables, ",") != want {
+		t.Errorf("got %v want %v", tables, want)
+	}
+	if findCell(cells, func(c *mxCell) bool { return c.Edge == "1" }) == nil {
+		t.Error("relation not found")
+	}
+}
+
+func TestArrow(t *testing.T) {
+	tests := []struct {
+		in   schema.Cardinality
+		want string
+	}{
+		{schema.ZeroOrOne, "ERzeroToOne"},
+		{schema.ExactlyOne, "ERmandOne"},
+		{schema.ZeroOrMore, "ERzeroToMany"},
+		{schema.OneOrMore, "ERoneToMany"},
+		{schema.UnknownCardinality, "none"},
+	}
+	for _, tt := range tests {
+		if got := arrow(tt.in); got != tt.want {
+			t.Errorf("got %v want %v", got, tt.want)
+		}
+	}
+}
+
+func parse(t *testing.T, b []byte) []*mxCell {
+	t.Helper()
+	f := &mxFile{}
+	if err := xml.Unmarshal(b, f); err != nil {
+		t.Fatal(err)
+	}
+	return f.Diagram.Model.Cells
+}
+
+func findCell(cells []*mxCell, fn func(c *mxCell) bool) *mxCell {
+	for _, c := range cells {
+		if fn(c) {
+			return c
+		}
+	}
+	return nil
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
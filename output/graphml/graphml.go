@@ -0,0 +1,306 @@
+package graphml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+const xmlns = "http://graphml.graphdrawing.org/xmlns"
+
+// The kinds of nodes and edges.
+const (
+	KindTable           = "table"
+	KindColumn          = "column"
+	KindRelation        = "relation"
+	KindColumnRelation  = "column_relation"
+	KindHasColumn       = "has_column"
+	KindReferencedTable = "referenced_table"
+)
+
+var _ output.Output = &GraphML{}
+
+// keys is the attributes of nodes and edges.
+var keys = []*key{
+	{ID: "kind", For: "all", Name: "kind", Type: "string"},
+	{ID: "label", For: "node", Name: "label", Type: "string"},
+	{ID: "type", For: "node", Name: "type", Type: "string"},
+	{ID: "labels", For: "node", Name: "labels", Type: "string"},
+	{ID: "columns", For: "node", Name: "columns", Type: "int"},
+	{ID: "comment", For: "node", Name: "comment", Type: "string"},
+	{ID: "table", For: "node", Name: "table", Type: "string"},
+	{ID: "nullable", For: "node", Name: "nullable", Type: "boolean"},
+	{ID: "pk", For: "node", Name: "pk", Type: "boolean"},
+	{ID: "cardinality", For: "edge", Name: "cardinality", Type: "string"},
+	{ID: "parent_cardinality", For: "edge", Name: "parent_cardinality", Type: "string"},
+	{ID: "virtual", For: "edge", Name: "virtual", Type: "boolean"},
+	{ID: "def", For: "edge", Name: "def", Type: "string"},
+}
+
+// GraphML struct.
+type GraphML struct {
+	config  *config.Config
+	columns bool
+}
+
+// New return GraphML. If columns is true, columns are output as nodes too.
+func New(c *config.Config, columns bool) *GraphML {
+	return &GraphML{
+		config:  c,
+		columns: columns,
+	}
+}
+
+type document struct {
+	XMLName xml.Name `xml:"graphml"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Keys    []*key   `xml:"key"`
+	Graph   *graph   `xml:"graph"`
+}
+
+type key struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graph struct {
+	ID          string  `xml:"id,attr"`
+	EdgeDefault string  `xml:"edgedefault,attr"`
+	Nodes       []*node `xml:"node"`
+	Edges       []*edge `xml:"edge"`
+}
+
+type node struct {
+	ID   string  `xml:"id,attr"`
+	Data []*data `xml:"data"`
+}
+
+type edge struct {
+	ID     string  `xml:"id,attr"`
+	Source string  `xml:"source,attr"`
+	Target string  `xml:"target,attr"`
+	Data   []*data `xml:"data"`
+}
+
+type data struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// builder builds the graph from tables and relations.
+type builder struct {
+	graph     *graph
+	columns   bool
+	tableIDs  map[string]string
+	columnIDs map[string]string
+}
+
+// OutputSchema output GraphML format for full relation.
+func (g *GraphML) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	b := g.newBuilder(s.Name)
+	for _, t := range s.Tables {
+		b.addTable(t)
+	}
+	for _, r := range s.Relations {
+		b.addRelation(r)
+	}
+	for _, t := range s.Tables {
+		b.addReferencedTables(t)
+	}
+	return write(wr, b.graph)
+}
+
+// OutputTable output GraphML format for table.
+func (g *GraphML) OutputTable(wr io.Writer, t *schema.Table) error {
+	tables, relations, err := t.CollectTablesAndRelations(*g.config.ER.Distance, true)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tables = output.AppendReferencedTables(tables)
+	b := g.newBuilder(t.Name)
+	for _, t := range tables {
+		b.addTable(t)
+	}
+	for _, r := range relations {
+		b.addRelation(r)
+	}
+	b.addReferencedTables(tables[0])
+	return write(wr, b.graph)
+}
+
+// OutputFunction output GraphML format for function (not supported).
+func (g *GraphML) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// GraphML format does not support individual function output
+	return nil
+}
+
+func (g *GraphML) newBuilder(name string) *builder {
+	return &builder{
+		graph: &graph{
+			ID:          name,
+			EdgeDefault: "directed",
+		},
+		columns:   g.columns,
+		tableIDs:  map[string]string{},
+		columnIDs: map[string]string{},
+	}
+}
+
+func (b *builder) addTable(t *schema.Table) {
+	if _, ok := b.tableIDs[t.Name]; ok {
+		return
+	}
+	id := fmt.Sprintf("t%d", len(b.tableIDs))
+	b.tableIDs[t.Name] = id
+	b.graph.Nodes = append(b.graph.Nodes, &node{
+		ID: id,
+		Data: []*data{
+			{Key: "kind", Value: KindTable},
+			{Key: "label", Value: t.Name},
+			{Key: "type", Value: t.Type},
+			{Key: "labels", Value: labelNames(t.Labels)},
+			{Key: "columns", Value: strconv.Itoa(len(t.Columns))},
+			{Key: "comment", Value: t.Comment},
+		},
+	})
+	if !b.columns {
+		return
+	}
+	pks := t.PrimaryKeyColumns()
+	for i, c := range t.Columns {
+		cid := fmt.Sprintf("%sc%d", id, i)
+		b.columnIDs[columnKey(t, c)] = cid
+		b.graph.Nodes = append(b.graph.Nodes, &node{
+			ID: cid,
+			Data: []*data{
+				{Key: "kind", Value: KindColumn},
+				{Key: "label", Value: fmt.Sprintf("%s.%s", t.Name, c.Name)},
+				{Key: "type", Value: c.Type},
+				{Key: "labels", Value: labelNames(c.Labels)},
+				{Key: "comment", Value: c.Comment},
+				{Key: "table", Value: t.Name},
+				{Key: "nullable", Value: strconv.FormatBool(c.Nullable)},
+				{Key: "pk", Value: strconv.FormatBool(lo.Contains(pks, c.Name))},
+			},
+		})
+		b.addEdge(id, cid, []*data{{Key: "kind", Value: KindHasColumn}})
+	}
+}
+
+func (b *builder) addRelation(r *schema.Relation) {
+	source, ok := b.tableIDs[r.Table.Name]
+	if !ok {
+		return
+	}
+	target, ok := b.tableIDs[r.ParentTable.Name]
+	if !ok {
+		return
+	}
+	attrs := func(kind string) []*data {
+		return []*data{
+			{Key: "kind", Value: kind},
+			{Key: "cardinality", Value: r.Cardinality.String()},
+			{Key: "parent_cardinality", Value: r.ParentCardinality.String()},
+			{Key: "virtual", Value: strconv.FormatBool(r.Virtual)},
+			{Key: "def", Value: r.Def},
+		}
+	}
+	b.addEdge(source, target, attrs(KindRelation))
+	if !b.columns {
+		return
+	}
+	for i, c := range r.Columns {
+		if i >= len(r.ParentColumns) {
+			break
+		}
+		source, ok := b.columnIDs[columnKey(r.Table, c)]
+		if !ok {
+			continue
+		}
+		target, ok := b.columnIDs[columnKey(r.ParentTable, r.ParentColumns[i])]
+		if !ok {
+			continue
+		}
+		b.addEdge(source, target, attrs(KindColumnRelation))
+	}
+}
+
+func (b *builder) addReferencedTables(t *schema.Table) {
+	source, ok := b.tableIDs[t.Name]
+	if !ok {
+		return
+	}
+	for _, rt := range t.ReferencedTables {
+		target, ok := b.tableIDs[rt.Name]
+		if !ok {
+			continue
+		}
+		b.addEdge(source, target, []*data{{Key: "kind", Value: KindReferencedTable}})
+	}
+}
+
+func (b *builder) addEdge(source, target string, d []*data) {
+	b.graph.Edges = append(b.graph.Edges, &edge{
+		ID:     fmt.Sprintf("e%d", len(b.graph.Edges)),
+		Source: source,
+		Target: target,
+		Data:   d,
+	})
+}
+
+func write(wr io.Writer, g *graph) error {
+	// omit empty attributes
+	for _, n := range g.Nodes {
+		n.Data = omitEmpty(n.Data)
+	}
+	for _, e := range g.Edges {
+		e.Data = omitEmpty(e.Data)
+	}
+	b, err := xml.MarshalIndent(&document{
+		Xmlns: xmlns,
+		Keys:  keys,
+		Graph: g,
+	}, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := io.WriteString(wr, xml.Header); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := wr.Write(b); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := io.WriteString(wr, "\n"); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func omitEmpty(d []*data) []*data {
+	omitted := []*data{}
+	for _, v := range d {
+		if v.Value == "" {
+			continue
+		}
+		omitted = append(omitted, v)
+	}
+	return omitted
+}
+
+func columnKey(t *schema.Table, c *schema.Column) string {
+	return fmt.Sprintf("%s\x00%s", t.Name, c.Name)
+}
+
+func labelNames(labels schema.Labels) string {
+	return strings.Join(lo.Map(labels, func(l *schema.Label, _ int) string { return l.Name }), ",")
+}
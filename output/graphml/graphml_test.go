@@ -0,0 +1,86 @@
+package graphml
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputSchema(t *testing.T) {
+	tests := []struct {
+		columns  bool
+		wantFile string
+	}{
+		{false, "graphml_test_schema"},
+		{true, "graphml_test_schema.column_nodes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantFile, func(t *testing.T) {
+			s := testutil.NewSchema(t)
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.ModifySchema(s); err != nil {
+				t.Fatal(err)
+			}
+			o := New(c, tt.columns)
+			got := &bytes.Buffer{}
+			if err := o.OutputSchema(got, s); err != nil {
+				t.Fatal(err)
+			}
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), tt.wantFile, got.String())
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), tt.wantFile, got.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(c, false)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Fatal(err)
+	}
+	f := "graphml_test_a"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -3,10 +3,12 @@ package gviz
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/beta/freetype/truetype"
@@ -62,7 +64,107 @@ func (g *Gviz) OutputViewpoint(wr io.Writer, v *schema.Viewpoint) error {
 	return g.render(wr, buf.Bytes())
 }
 
-func (g *Gviz) render(wr io.Writer, b []byte) (e error) {
+// Position is the position and the size of the node laid out by Graphviz.
+// The coordinates are in points with the origin at the top left of the graph.
+type Position struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// LayoutSchema return the positions of the tables laid out for full relation.
+func (g *Gviz) LayoutSchema(s *schema.Schema) (map[string]*Position, error) {
+	buf := &bytes.Buffer{}
+	if err := g.dot.OutputSchema(buf, s); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return g.layout(buf.Bytes())
+}
+
+// LayoutTable return the positions of the tables laid out for table.
+func (g *Gviz) LayoutTable(t *schema.Table) (map[string]*Position, error) {
+	buf := &bytes.Buffer{}
+	if err := g.dot.OutputTable(buf, t); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return g.layout(buf.Bytes())
+}
+
+type layoutGraph struct {
+	BB      string `json:"bb"`
+	Objects []struct {
+		Name   string `json:"name"`
+		Pos    string `json:"pos"`
+		Width  string `json:"width"`
+		Height string `json:"height"`
+	} `json:"objects"`
+}
+
+func (g *Gviz) layout(b []byte) (map[string]*Position, error) {
+	buf := &bytes.Buffer{}
+	if err := g.renderFormat(buf, b, graphviz.Format("json")); err != nil {
+		return nil, err
+	}
+	lg := &layoutGraph{}
+	if err := json.Unmarshal(buf.Bytes(), lg); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bb, err := parsePoints(lg.BB, 4)
+	if err != nil {
+		return nil, err
+	}
+	positions := map[string]*Position{}
+	for _, o := range lg.Objects {
+		// subgraphs (clusters) have no position
+		if o.Pos == "" {
+			continue
+		}
+		pos, err := parsePoints(o.Pos, 2)
+		if err != nil {
+			return nil, err
+		}
+		w, err := strconv.ParseFloat(o.Width, 64)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		h, err := strconv.ParseFloat(o.Height, 64)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// Graphviz uses the center of the node, inches for the size and the origin at the bottom left.
+		w, h = w*72, h*72
+		positions[o.Name] = &Position{
+			X:      pos[0] - w/2 - bb[0],
+			Y:      bb[3] - pos[1] - h/2,
+			Width:  w,
+			Height: h,
+		}
+	}
+	return positions, nil
+}
+
+func parsePoints(v string, n int) ([]float64, error) {
+	s := strings.Split(v, ",")
+	if len(s) != n {
+		return nil, fmt.Errorf("invalid points: %s", v)
+	}
+	points := make([]float64, 0, n)
+	for _, p := range s {
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		points = append(points, f)
+	}
+	return points, nil
+}
+
+func (g *Gviz) render(wr io.Writer, b []byte) error {
+	return g.renderFormat(wr, b, graphviz.Format(g.config.ER.Format))
+}
+
+func (g *Gviz) renderFormat(wr io.Writer, b []byte, format graphviz.Format) (e error) {
 	ctx := context.Background()
 	gviz, err := graphviz.New(ctx)
 	if err != nil {
@@ -90,7 +192,7 @@ func (g *Gviz) render(wr io.Writer, b []byte) (e error) {
 			e = errors.WithStack(err)
 		}
 	}()
-	if err := gviz.Render(ctx, graph, graphviz.Format(g.config.ER.Format), wr); err != nil {
+	if err := gviz.Render(ctx, graph, format, wr); err != nil {
 		return errors.WithStack(err)
 	}
 	return nil
@@ -93,6 +93,42 @@ func TestOutputTable(t *testing.T) {
 	}
 }
 
+func TestLayoutSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.MergeAdditionalData(s); err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got, err := o.LayoutSchema(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(s.Tables) {
+		t.Errorf("got %v want %v", len(got), len(s.Tables))
+	}
+	for _, ta := range s.Tables {
+		p, ok := got[ta.Name]
+		if !ok {
+			t.Errorf("position of %s not found", ta.Name)
+			continue
+		}
+		if p.X < 0 || p.Y < 0 || p.Width <= 0 || p.Height <= 0 {
+			t.Errorf("invalid position of %s: %#v", ta.Name, p)
+		}
+	}
+	// table a is the parent of table b, so it is laid out below table b.
+	if got["a"].Y <= got["b"].Y {
+		t.Errorf("got a.Y %v <= b.Y %v", got["a"].Y, got["b"].Y)
+	}
+}
+
 func testdataDir() string {
 	wd, _ := os.Getwd()
 	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
@@ -0,0 +1,102 @@
+(function () {
+  'use strict';
+
+  var maxResults = 50;
+  var input = document.getElementById('tbls-search');
+  var results = document.getElementById('tbls-search-results');
+  var index = window.tblsSearchIndex || [];
+  var active = -1;
+
+  var entries = index.map(function (e) {
+    return { entry: e, name: e.name.toLowerCase(), text: (e.name + ' ' + (e.comment || '')).toLowerCase() };
+  });
+
+  function search(query) {
+    var terms = query.toLowerCase().split(/\s+/).filter(function (t) { return t !== ''; });
+    if (terms.length === 0) {
+      return [];
+    }
+    var hits = [];
+    entries.forEach(function (e) {
+      var all = terms.every(function (t) { return e.text.indexOf(t) !== -1; });
+      if (!all) {
+        return;
+      }
+      // rank matches in names above matches in comments
+      var score = terms.filter(function (t) { return e.name.indexOf(t) !== -1; }).length;
+      hits.push({ entry: e.entry, score: score });
+    });
+    hits.sort(function (a, b) { return b.score - a.score; });
+    return hits.slice(0, maxResults).map(function (h) { return h.entry; });
+  }
+
+  function render(hits) {
+    results.innerHTML = '';
+    active = -1;
+    hits.forEach(function (e) {
+      var li = document.createElement('li');
+      var a = document.createElement('a');
+      a.href = e.url;
+      var type = document.createElement('span');
+      type.className = 'type';
+      type.textContent = e.type;
+      a.appendChild(type);
+      a.appendChild(document.createTextNode(e.name));
+      if (e.comment) {
+        var desc = document.createElement('span');
+        desc.className = 'desc';
+        desc.textContent = e.comment;
+        a.appendChild(desc);
+      }
+      li.appendChild(a);
+      results.appendChild(li);
+    });
+    results.className = hits.length > 0 ? 'open' : '';
+  }
+
+  function move(d) {
+    var items = results.getElementsByTagName('li');
+    if (items.length === 0) {
+      return;
+    }
+    if (active >= 0) {
+      items[active].className = '';
+    }
+    active = (active + d + items.length) % items.length;
+    items[active].className = 'active';
+    items[active].scrollIntoView({ block: 'nearest' });
+  }
+
+  if (!input || !results) {
+    return;
+  }
+  input.addEventListener('input', function () {
+    render(search(input.value));
+  });
+  input.addEventListener('keydown', function (ev) {
+    switch (ev.key) {
+      case 'ArrowDown':
+        move(1);
+        ev.preventDefault();
+        break;
+      case 'ArrowUp':
+        move(-1);
+        ev.preventDefault();
+        break;
+      case 'Enter':
+        var items = results.getElementsByTagName('a');
+        if (items.length > 0) {
+          window.location.href = items[active >= 0 ? active : 0].href;
+        }
+        break;
+      case 'Escape':
+        results.className = '';
+        break;
+    }
+  });
+  document.addEventListener('click', function (ev) {
+    if (ev.target !== input && !results.contains(ev.target)) {
+      results.className = '';
+    }
+  });
+})();
@@ -0,0 +1,400 @@
+package html
+
+import (
+	"bytes"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/output/gviz"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// DefaultERFormat is the image format of ER diagrams inlined in the HTML pages when ER.Format can not be inlined.
+const DefaultERFormat = "svg"
+
+// SearchIndexFileName is the file name of the search index in the document directory.
+const SearchIndexFileName = "search-index.js"
+
+// Assets is the file names of the static assets in the document directory.
+var Assets = []string{"tbls.css", "tbls.js"}
+
+var _ output.Output = &HTML{}
+
+//go:embed templates/* assets/*
+var tmpl embed.FS
+
+var erMimeTypes = map[string]string{
+	"svg": "image/svg+xml",
+	"png": "image/png",
+	"jpg": "image/jpeg",
+}
+
+// HTML struct.
+type HTML struct {
+	config *config.Config
+	tmpl   embed.FS
+	name   string
+}
+
+// SearchEntry is the entry of the search index.
+type SearchEntry struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	URL     string `json:"url"`
+}
+
+// New return HTML.
+func New(c *config.Config) *HTML {
+	return &HTML{
+		config: c,
+		tmpl:   tmpl,
+		name:   c.Name,
+	}
+}
+
+// OutputSchema output .html format for all tables.
+func (h *HTML) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	h.name = s.Name
+	templateData := h.makePageData(s.Name)
+	templateData["Schema"] = s
+	templateData["Enums"] = sortedEnums(s.Enums)
+	if h.config.ER.Viewer {
+		templateData["erViewer"] = fmt.Sprintf("%s%s", h.config.BaseURL, config.ERViewerFileName)
+	}
+	if !h.config.ER.Skip {
+		er, err := h.renderER(func(g *gviz.Gviz, wr io.Writer) error {
+			return g.OutputSchema(wr, s)
+		})
+		if err != nil {
+			return err
+		}
+		templateData["er"] = er
+	}
+	return h.execute(wr, "index.html.tmpl", templateData)
+}
+
+// OutputTable output html format for table.
+func (h *HTML) OutputTable(wr io.Writer, t *schema.Table) error {
+	templateData := h.makePageData(t.Name)
+	templateData["Table"] = t
+	if !h.config.ER.Skip {
+		er, err := h.renderER(func(g *gviz.Gviz, wr io.Writer) error {
+			return g.OutputTable(wr, t)
+		})
+		if err != nil {
+			return err
+		}
+		templateData["er"] = er
+	}
+	return h.execute(wr, "table.html.tmpl", templateData)
+}
+
+// OutputFunction output html format for function.
+func (h *HTML) OutputFunction(wr io.Writer, f *schema.Function) error {
+	templateData := h.makePageData(f.Name)
+	templateData["Function"] = f
+	return h.execute(wr, "function.html.tmpl", templateData)
+}
+
+// OutputViewpoint output html format for viewpoint.
+func (h *HTML) OutputViewpoint(wr io.Writer, i int, v *schema.Viewpoint) error {
+	templateData := h.makePageData(v.Name)
+	templateData["Viewpoint"] = v
+	groups := []map[string]interface{}{}
+	nogroup := v.Schema.Tables
+	for _, g := range v.Groups {
+		tables, _, err := v.Schema.SeparateTablesThatAreIncludedOrNot(&schema.FilterOption{
+			Include:       g.Tables,
+			IncludeLabels: g.Labels,
+		})
+		if err != nil {
+			return err
+		}
+		groups = append(groups, map[string]interface{}{
+			"Name":   g.Name,
+			"Desc":   g.Desc,
+			"Tables": tables,
+		})
+		nogroup = lo.Without(nogroup, tables...)
+	}
+	if len(v.Groups) > 0 && len(nogroup) > 0 {
+		groups = append(groups, map[string]interface{}{
+			"Name":   "-",
+			"Desc":   "",
+			"Tables": nogroup,
+		})
+	}
+	templateData["Groups"] = groups
+	if !h.config.ER.Skip {
+		er, err := h.renderER(func(g *gviz.Gviz, wr io.Writer) error {
+			return g.OutputViewpoint(wr, v)
+		})
+		if err != nil {
+			return err
+		}
+		templateData["er"] = er
+	}
+	return h.execute(wr, "viewpoint.html.tmpl", templateData)
+}
+
+// OutputSearchIndex output the search index of tables, columns, viewpoints and functions as JavaScript.
+// The index is loaded by <script> instead of fetch() so that the pages also work from file://.
+func (h *HTML) OutputSearchIndex(wr io.Writer, s *schema.Schema) error {
+	entries := []*SearchEntry{}
+	for _, t := range s.Tables {
+		entries = append(entries, &SearchEntry{
+			Type:    "table",
+			Name:    t.Name,
+			Comment: t.Comment,
+			URL:     h.tableHref(t.Name),
+		})
+		for _, c := range t.Columns {
+			entries = append(entries, &SearchEntry{
+				Type:    "column",
+				Name:    fmt.Sprintf("%s.%s", t.Name, c.Name),
+				Comment: c.Comment,
+				URL:     fmt.Sprintf("%s#%s", h.tableHref(t.Name), columnAnchor(c.Name)),
+			})
+		}
+	}
+	for i, v := range s.Viewpoints {
+		entries = append(entries, &SearchEntry{
+			Type:    "viewpoint",
+			Name:    v.Name,
+			Comment: v.Desc,
+			URL:     h.viewpointHref(i),
+		})
+	}
+	for _, f := range s.Functions {
+		entries = append(entries, &SearchEntry{
+			Type: "function",
+			Name: f.Name,
+			URL:  h.functionHref(f.Name),
+		})
+	}
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := fmt.Fprintf(wr, "window.tblsSearchIndex = %s;\n", b); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Output generate html files.
+func Output(s *schema.Schema, c *config.Config, force bool) error {
+	docPath := c.DocPath
+
+	fullPath, err := filepath.Abs(docPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !force && outputExists(s, fullPath) {
+		return errors.New("output files already exists")
+	}
+
+	if err := os.MkdirAll(fullPath, 0755); err != nil { // #nosec
+		return errors.WithStack(err)
+	}
+
+	h := New(c)
+	h.name = s.Name
+	write := func(fn string, out func(wr io.Writer) error) error {
+		f, err := os.Create(filepath.Clean(filepath.Join(fullPath, fn)))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := out(f); err != nil {
+			_ = f.Close()
+			return errors.WithStack(err)
+		}
+		fmt.Printf("%s\n", filepath.Join(docPath, fn))
+		return f.Close()
+	}
+
+	// index.html
+	if err := write("index.html", func(wr io.Writer) error {
+		return h.OutputSchema(wr, s)
+	}); err != nil {
+		return err
+	}
+
+	// tables
+	for _, t := range s.Tables {
+		if err := write(fmt.Sprintf("%s.html", t.Name), func(wr io.Writer) error {
+			return h.OutputTable(wr, t)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// viewpoints
+	for i, v := range s.Viewpoints {
+		if err := write(fmt.Sprintf("viewpoint-%d.html", i), func(wr io.Writer) error {
+			return h.OutputViewpoint(wr, i, v)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// functions
+	for _, fn := range s.Functions {
+		if err := write(fmt.Sprintf("%s.html", fn.Name), func(wr io.Writer) error {
+			return h.OutputFunction(wr, fn)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// search index and assets
+	if err := write(SearchIndexFileName, func(wr io.Writer) error {
+		return h.OutputSearchIndex(wr, s)
+	}); err != nil {
+		return err
+	}
+	for _, a := range Assets {
+		b, err := h.tmpl.ReadFile(fmt.Sprintf("assets/%s", a))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := write(a, func(wr io.Writer) error {
+			_, err := wr.Write(b)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HTML) execute(wr io.Writer, name string, data map[string]interface{}) error {
+	tmpl, err := template.New(name).Funcs(h.funcs()).ParseFS(h.tmpl, "templates/*.tmpl")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := tmpl.ExecuteTemplate(wr, name, data); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (h *HTML) makePageData(title string) map[string]interface{} {
+	return map[string]interface{}{
+		"Title":      title,
+		"SchemaName": h.name,
+		"IndexHref":  fmt.Sprintf("%sindex.html", h.config.BaseURL),
+	}
+}
+
+// renderER render the ER diagram by gviz and return it as a data URI.
+func (h *HTML) renderER(render func(g *gviz.Gviz, wr io.Writer) error) (template.URL, error) {
+	format := h.config.ER.Format
+	if _, ok := erMimeTypes[format]; !ok {
+		// mermaid needs JavaScript from CDN, so render it as an image instead.
+		h.config.ER.Format = DefaultERFormat
+		defer func() {
+			h.config.ER.Format = format
+		}()
+	}
+	buf := &bytes.Buffer{}
+	if err := render(gviz.New(h.config), buf); err != nil {
+		return "", errors.WithStack(err)
+	}
+	// #nosec G203
+	return template.URL(fmt.Sprintf("data:%s;base64,%s", erMimeTypes[h.config.ER.Format], base64.StdEncoding.EncodeToString(buf.Bytes()))), nil
+}
+
+func (h *HTML) funcs() template.FuncMap {
+	return template.FuncMap{
+		"lookup": func(text string) string {
+			return h.config.MergedDict.Lookup(text)
+		},
+		"first_paragraph": func(text string) string {
+			if h.config.Format.ShowOnlyFirstParagraph {
+				return output.ShowOnlyFirstParagraph(text)
+			}
+			return text
+		},
+		"show_column": func(t *schema.Table, name string) bool {
+			return t.ShowColumn(name, h.config.Format.HideColumnsWithoutValues)
+		},
+		"table_href":     h.tableHref,
+		"function_href":  h.functionHref,
+		"viewpoint_href": h.viewpointHref,
+		"column_anchor":  columnAnchor,
+		"children": func(c *schema.Column) []string {
+			return lo.Uniq(lo.Map(c.ChildRelations, func(r *schema.Relation, _ int) string { return r.Table.Name }))
+		},
+		"parents": func(c *schema.Column) []string {
+			return lo.Uniq(lo.Map(c.ParentRelations, func(r *schema.Relation, _ int) string { return r.ParentTable.Name }))
+		},
+		"has_comment": func(v interface{}) bool {
+			switch v := v.(type) {
+			case []*schema.Constraint:
+				return lo.ContainsBy(v, func(c *schema.Constraint) bool { return c.Comment != "" })
+			case []*schema.Index:
+				return lo.ContainsBy(v, func(i *schema.Index) bool { return i.Comment != "" })
+			case []*schema.Trigger:
+				return lo.ContainsBy(v, func(t *schema.Trigger) bool { return t.Comment != "" })
+			}
+			return false
+		},
+		"has_labels": func(tables []*schema.Table) bool {
+			return lo.ContainsBy(tables, func(t *schema.Table) bool { return len(t.Labels) > 0 })
+		},
+	}
+}
+
+func (h *HTML) tableHref(name string) string {
+	return fmt.Sprintf("%s%s.html", h.config.BaseURL, url.PathEscape(name))
+}
+
+func (h *HTML) functionHref(name string) string {
+	return fmt.Sprintf("%s%s.html", h.config.BaseURL, url.PathEscape(name))
+}
+
+func (h *HTML) viewpointHref(i int) string {
+	return fmt.Sprintf("%sviewpoint-%d.html", h.config.BaseURL, i)
+}
+
+func columnAnchor(name string) string {
+	return fmt.Sprintf("column-%s", strings.ReplaceAll(name, " ", "-"))
+}
+
+func sortedEnums(enums []*schema.Enum) []*schema.Enum {
+	for _, e := range enums {
+		sort.Strings(e.Values)
+	}
+	return enums
+}
+
+func outputExists(s *schema.Schema, path string) bool {
+	// index.html
+	if _, err := os.Lstat(filepath.Join(path, "index.html")); err == nil {
+		return true
+	}
+	// tables
+	for _, t := range s.Tables {
+		if _, err := os.Lstat(filepath.Join(path, fmt.Sprintf("%s.html", t.Name))); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name                   string
+		showOnlyFirstParagraph bool
+		gotFile                string
+		wantFile               string
+	}{
+		{"index.html", false, "index.html", "html_test_index.html"},
+		{"a.html", false, "a.html", "html_test_a.html"},
+		{"view.html", false, "view.html", "html_test_view.html"},
+		{"viewpoint-1.html", false, "viewpoint-1.html", "html_test_viewpoint-1.html"},
+		{"viewpoint-2.html", false, "viewpoint-2.html", "html_test_viewpoint-2.html"},
+		{"showOnlyFirstParagraph index.html", true, "index.html", "html_test_index.html.first_para"},
+		{"search-index.js", false, "search-index.js", "html_test_search-index.js"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testutil.NewSchema(t)
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tempDir := t.TempDir()
+			opts := []config.Option{
+				config.DocPath(tempDir),
+				config.ERSkip(true),
+			}
+			if err := c.Load(filepath.Join(testdataDir(), "out_test_tbls.yml"), opts...); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.ModifySchema(s); err != nil {
+				t.Fatal(err)
+			}
+			c.Format.ShowOnlyFirstParagraph = tt.showOnlyFirstParagraph
+			if err := Output(s, c, true); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(filepath.Join(tempDir, tt.gotFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), tt.wantFile, got)
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), tt.wantFile, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOutputER(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"svg", `src="data:image/svg&#43;xml;base64,`},
+		{"png", `src="data:image/png;base64,`},
+		{"mermaid", `src="data:image/svg&#43;xml;base64,`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			s := testutil.NewSchema(t)
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tempDir := t.TempDir()
+			opts := []config.Option{
+				config.DocPath(tempDir),
+				config.ERFormat(tt.format),
+			}
+			if err := c.Load(filepath.Join(testdataDir(), "out_test_tbls.yml"), opts...); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.ModifySchema(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := Output(s, c, true); err != nil {
+				t.Fatal(err)
+			}
+			for _, fn := range []string{"index.html", "a.html", "viewpoint-1.html"} {
+				got, err := os.ReadFile(filepath.Join(tempDir, fn))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(got), tt.want) {
+					t.Errorf("%s does not contain the inlined ER diagram %s", fn, tt.want)
+				}
+			}
+			if c.ER.Format != tt.format {
+				t.Errorf("got %v\nwant %v", c.ER.Format, tt.format)
+			}
+		})
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
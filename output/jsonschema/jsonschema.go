@@ -0,0 +1,478 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// Draft is the dialect of JSON Schema.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var _ output.Output = &JSONSchema{}
+
+var (
+	typeArgsRe    = regexp.MustCompile(`^([^(]+)\(([^)]*)\)(.*)$`)
+	enumTypeRe    = regexp.MustCompile(`^(?i)enum\s*\((.*)\)$`)
+	valueRe       = regexp.MustCompile(`^\s*(?:'((?:[^']|'')*)'|([-+]?[0-9]+(?:\.[0-9]+)?))\s*(?:,|$)`)
+	castRe        = regexp.MustCompile(`::[a-zA-Z_][a-zA-Z0-9_ ]*(\[\])?`)
+	introducerRe  = regexp.MustCompile(`(?i)(^|[\s(,=])_(?:utf8mb4|utf8mb3|utf8|latin1|ascii|binary)'`)
+	quotedIdentRe = regexp.MustCompile("(?:`([^`]+)`|\"([^\"]+)\"|\\[([A-Za-z_][A-Za-z0-9_ ]*)\\])")
+	identParenRe  = regexp.MustCompile(`\(\s*([A-Za-z_][A-Za-z0-9_]*)\s*\)`)
+	checkInRe     = regexp.MustCompile(`(?i)^([A-Za-z_][A-Za-z0-9_]*)\s+IN\s*\((.*)\)$`)
+	checkAnyRe    = regexp.MustCompile(`(?i)^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*ANY\s*\(*\s*ARRAY\s*\[(.*)\]\s*\)*$`)
+	checkEqRe     = regexp.MustCompile(`(?i)^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.+)$`)
+	orRe          = regexp.MustCompile(`(?i)\s+OR\s+`)
+)
+
+// integerRanges is the ranges of the integer types. The second range is for the unsigned types.
+var integerRanges = map[string][2][2]string{
+	"tinyint":     {{"-128", "127"}, {"0", "255"}},
+	"smallint":    {{"-32768", "32767"}, {"0", "65535"}},
+	"int2":        {{"-32768", "32767"}, {"0", "65535"}},
+	"smallserial": {{"-32768", "32767"}, {"0", "65535"}},
+	"serial2":     {{"-32768", "32767"}, {"0", "65535"}},
+	"mediumint":   {{"-8388608", "8388607"}, {"0", "16777215"}},
+	"int":         {{"-2147483648", "2147483647"}, {"0", "4294967295"}},
+	"integer":     {{"-2147483648", "2147483647"}, {"0", "4294967295"}},
+	"int4":        {{"-2147483648", "2147483647"}, {"0", "4294967295"}},
+	"serial":      {{"-2147483648", "2147483647"}, {"0", "4294967295"}},
+	"serial4":     {{"-2147483648", "2147483647"}, {"0", "4294967295"}},
+	"bigint":      {{"-9223372036854775808", "9223372036854775807"}, {"0", "18446744073709551615"}},
+	"int8":        {{"-9223372036854775808", "9223372036854775807"}, {"0", "18446744073709551615"}},
+	"bigserial":   {{"-9223372036854775808", "9223372036854775807"}, {"0", "18446744073709551615"}},
+	"serial8":     {{"-9223372036854775808", "9223372036854775807"}, {"0", "18446744073709551615"}},
+}
+
+// types is the JSON types and the formats of the column types.
+var types = map[string][2]string{
+	"bool":                        {"boolean", ""},
+	"boolean":                     {"boolean", ""},
+	"bit":                         {"boolean", ""},
+	"decimal":                     {"number", ""},
+	"numeric":                     {"number", ""},
+	"number":                      {"number", ""},
+	"money":                       {"number", ""},
+	"smallmoney":                  {"number", ""},
+	"real":                        {"number", ""},
+	"float":                       {"number", ""},
+	"float4":                      {"number", ""},
+	"float8":                      {"number", ""},
+	"double":                      {"number", ""},
+	"double precision":            {"number", ""},
+	"char":                        {"string", ""},
+	"character":                   {"string", ""},
+	"bpchar":                      {"string", ""},
+	"nchar":                       {"string", ""},
+	"varchar":                     {"string", ""},
+	"character varying":           {"string", ""},
+	"nvarchar":                    {"string", ""},
+	"varchar2":                    {"string", ""},
+	"nvarchar2":                   {"string", ""},
+	"text":                        {"string", ""},
+	"tinytext":                    {"string", ""},
+	"mediumtext":                  {"string", ""},
+	"longtext":                    {"string", ""},
+	"ntext":                       {"string", ""},
+	"citext":                      {"string", ""},
+	"clob":                        {"string", ""},
+	"string":                      {"string", ""},
+	"set":                         {"string", ""},
+	"uuid":                        {"string", "uuid"},
+	"uniqueidentifier":            {"string", "uuid"},
+	"date":                        {"string", "date"},
+	"time":                        {"string", "time"},
+	"time without time zone":      {"string", "time"},
+	"time with time zone":         {"string", "time"},
+	"timetz":                      {"string", "time"},
+	"timestamp":                   {"string", "date-time"},
+	"timestamp without time zone": {"string", "date-time"},
+	"timestamp with time zone":    {"string", "date-time"},
+	"timestamptz":                 {"string", "date-time"},
+	"datetime":                    {"string", "date-time"},
+	"datetime2":                   {"string", "date-time"},
+	"smalldatetime":               {"string", "date-time"},
+	"datetimeoffset":              {"string", "date-time"},
+	"interval":                    {"string", "duration"},
+	"inet":                        {"string", ""},
+	"cidr":                        {"string", ""},
+	"macaddr":                     {"string", ""},
+	"xml":                         {"string", ""},
+	"bytea":                       {"string", ""},
+	"blob":                        {"string", ""},
+	"tinyblob":                    {"string", ""},
+	"mediumblob":                  {"string", ""},
+	"longblob":                    {"string", ""},
+	"binary":                      {"string", ""},
+	"varbinary":                   {"string", ""},
+	"image":                       {"string", ""},
+	"bytes":                       {"string", ""},
+}
+
+// binaryTypes is the column types whose values are encoded in base64.
+var binaryTypes = []string{"bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "image", "bytes"}
+
+// JSONSchema struct.
+type JSONSchema struct {
+	config *config.Config
+	enums  []*schema.Enum
+}
+
+// New return JSONSchema.
+func New(c *config.Config) *JSONSchema {
+	return &JSONSchema{
+		config: c,
+	}
+}
+
+// SetSchema set the schema of the tables output by OutputTable to resolve the enum types of the columns.
+func (j *JSONSchema) SetSchema(s *schema.Schema) {
+	j.enums = s.Enums
+}
+
+type document struct {
+	Schema      string   `json:"$schema"`
+	Ref         string   `json:"$ref,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Defs        *ordered `json:"$defs"`
+}
+
+type definition struct {
+	Type                 string   `json:"type"`
+	Title                string   `json:"title"`
+	Description          string   `json:"description,omitempty"`
+	Properties           *ordered `json:"properties"`
+	Required             []string `json:"required,omitempty"`
+	AdditionalProperties bool     `json:"additionalProperties"`
+}
+
+type property struct {
+	Type            any         `json:"type,omitempty"`
+	Format          string      `json:"format,omitempty"`
+	ContentEncoding string      `json:"contentEncoding,omitempty"`
+	Description     string      `json:"description,omitempty"`
+	Enum            []any       `json:"enum,omitempty"`
+	MaxLength       *int        `json:"maxLength,omitempty"`
+	Minimum         json.Number `json:"minimum,omitempty"`
+	Maximum         json.Number `json:"maximum,omitempty"`
+	Items           *property   `json:"items,omitempty"`
+}
+
+// ordered is the JSON object that keeps the order of the keys.
+type ordered struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrdered() *ordered {
+	return &ordered{values: map[string]any{}}
+}
+
+func (o *ordered) set(k string, v any) {
+	if _, ok := o.values[k]; !ok {
+		o.keys = append(o.keys, k)
+	}
+	o.values[k] = v
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *ordered) MarshalJSON() ([]byte, error) {
+	b := &bytes.Buffer{}
+	b.WriteString("{")
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// OutputSchema output JSON Schema that has the definitions of the tables.
+func (j *JSONSchema) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	doc := &document{
+		Schema:      Draft,
+		Title:       s.Name,
+		Description: s.Desc,
+		Defs:        newOrdered(),
+	}
+	for _, t := range s.Tables {
+		doc.Defs.set(t.Name, newDefinition(t, s.Enums))
+	}
+	return write(wr, doc)
+}
+
+// OutputTable output JSON Schema of the table. The rows of the table are validated by the document.
+func (j *JSONSchema) OutputTable(wr io.Writer, t *schema.Table) error {
+	doc := &document{
+		Schema: Draft,
+		Ref:    fmt.Sprintf("#/$defs/%s", strings.NewReplacer("~", "~0", "/", "~1").Replace(t.Name)),
+		Defs:   newOrdered(),
+	}
+	doc.Defs.set(t.Name, newDefinition(t, j.enums))
+	return write(wr, doc)
+}
+
+// OutputFunction output JSON Schema for function (not supported).
+func (j *JSONSchema) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// JSON Schema format does not support functions
+	return nil
+}
+
+func write(wr io.Writer, doc *document) error {
+	encoder := json.NewEncoder(wr)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func newDefinition(t *schema.Table, enums []*schema.Enum) *definition {
+	d := &definition{
+		Type:                 "object",
+		Title:                t.Name,
+		Description:          t.Comment,
+		Properties:           newOrdered(),
+		Required:             []string{},
+		AdditionalProperties: false,
+	}
+	checks := checkEnums(t)
+	for _, c := range t.Columns {
+		p := newProperty(c, enums)
+		if p.Enum == nil {
+			if values, ok := checks[c.Name]; ok {
+				p.Enum = values
+			}
+		}
+		if c.Nullable {
+			p.nullable()
+		} else {
+			d.Required = append(d.Required, c.Name)
+		}
+		d.Properties.set(c.Name, p)
+	}
+	return d
+}
+
+// newProperty return the property of the column. The nullability is not applied.
+func newProperty(c *schema.Column, enums []*schema.Enum) *property {
+	p := typeProperty(c.Type, enums)
+	p.Description = c.Comment
+	return p
+}
+
+func typeProperty(typ string, enums []*schema.Enum) *property {
+	typ = strings.TrimSpace(typ)
+	if strings.HasSuffix(typ, "[]") {
+		return &property{
+			Type:  "array",
+			Items: typeProperty(strings.TrimSuffix(typ, "[]"), enums),
+		}
+	}
+	if m := enumTypeRe.FindStringSubmatch(typ); m != nil {
+		if values, ok := parseValues(m[1]); ok {
+			return &property{Type: "string", Enum: values}
+		}
+	}
+	for _, e := range enums {
+		if strings.EqualFold(e.Name, typ) || strings.HasSuffix(strings.ToLower(e.Name), "."+strings.ToLower(typ)) {
+			values := []any{}
+			for _, v := range e.Values {
+				values = append(values, v)
+			}
+			return &property{Type: "string", Enum: values}
+		}
+	}
+	name, args, unsigned := parseType(typ)
+	if r, ok := integerRanges[name]; ok {
+		if name == "tinyint" && len(args) == 1 && args[0] == "1" {
+			// tinyint(1) is the boolean of MySQL
+			return &property{Type: "boolean"}
+		}
+		rr := r[0]
+		if unsigned {
+			rr = r[1]
+		}
+		return &property{Type: "integer", Minimum: json.Number(rr[0]), Maximum: json.Number(rr[1])}
+	}
+	t, ok := types[name]
+	if !ok {
+		// unknown types accept any values
+		return &property{}
+	}
+	p := &property{Type: t[0], Format: t[1]}
+	switch {
+	case name == "bit" && len(args) == 1 && args[0] != "1":
+		p = &property{Type: "string"}
+	case lo.Contains(binaryTypes, name):
+		p.ContentEncoding = "base64"
+	case t[0] == "string" && t[1] == "" && len(args) == 1:
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			p.MaxLength = &n
+		}
+	}
+	return p
+}
+
+// nullable allow null for the property.
+func (p *property) nullable() {
+	switch v := p.Type.(type) {
+	case string:
+		p.Type = []string{v, "null"}
+	}
+	if p.Enum != nil {
+		p.Enum = append(p.Enum, nil)
+	}
+}
+
+// parseType return the lower-cased name and the arguments of the type, and whether the type is unsigned.
+func parseType(typ string) (string, []string, bool) {
+	t := strings.ToLower(strings.TrimSpace(typ))
+	args := []string{}
+	if m := typeArgsRe.FindStringSubmatch(t); m != nil {
+		t = fmt.Sprintf("%s %s", strings.TrimSpace(m[1]), strings.TrimSpace(m[3]))
+		for _, a := range strings.Split(m[2], ",") {
+			args = append(args, strings.TrimSpace(a))
+		}
+	}
+	unsigned := false
+	fields := []string{}
+	for _, f := range strings.Fields(t) {
+		switch f {
+		case "unsigned":
+			unsigned = true
+		case "zerofill", "identity":
+		default:
+			fields = append(fields, f)
+		}
+	}
+	return strings.Join(fields, " "), args, unsigned
+}
+
+// checkEnums return the values of the columns that are constrained by the CHECK constraints.
+// e.g. `CHECK (status IN ('a', 'b'))`, `CHECK ((status = ANY (ARRAY['a'::text, 'b'::text])))` and `([status]='a' OR [status]='b')`.
+func checkEnums(t *schema.Table) map[string][]any {
+	enums := map[string][]any{}
+	for _, c := range t.Constraints {
+		if !strings.EqualFold(c.Type, "CHECK") {
+			continue
+		}
+		column, values, ok := parseCheck(c.Def)
+		if !ok {
+			continue
+		}
+		for _, col := range t.Columns {
+			if strings.EqualFold(col.Name, column) {
+				if _, ok := enums[col.Name]; !ok {
+					enums[col.Name] = values
+				}
+				break
+			}
+		}
+	}
+	return enums
+}
+
+func parseCheck(def string) (string, []any, bool) {
+	d := strings.TrimSpace(def)
+	if strings.HasPrefix(strings.ToUpper(d), "CHECK") {
+		d = strings.TrimSpace(d[len("CHECK"):])
+	}
+	d = castRe.ReplaceAllString(d, "")
+	d = introducerRe.ReplaceAllString(d, "$1'")
+	d = quotedIdentRe.ReplaceAllString(d, "$1$2$3")
+	for {
+		r := identParenRe.ReplaceAllString(d, "$1")
+		if r == d {
+			break
+		}
+		d = r
+	}
+	d = unwrap(d)
+	if m := checkInRe.FindStringSubmatch(d); m != nil {
+		values, ok := parseValues(m[2])
+		return m[1], values, ok
+	}
+	if m := checkAnyRe.FindStringSubmatch(d); m != nil {
+		values, ok := parseValues(m[2])
+		return m[1], values, ok
+	}
+	column := ""
+	values := []any{}
+	for _, cond := range orRe.Split(d, -1) {
+		m := checkEqRe.FindStringSubmatch(unwrap(strings.TrimSpace(cond)))
+		if m == nil || (column != "" && !strings.EqualFold(column, m[1])) {
+			return "", nil, false
+		}
+		column = m[1]
+		v, ok := parseValues(m[2])
+		if !ok || len(v) != 1 {
+			return "", nil, false
+		}
+		values = append(values, v...)
+	}
+	return column, values, column != ""
+}
+
+// parseValues parse the list of the literals. e.g. `'a', 'b'`, `1, 2`.
+func parseValues(list string) ([]any, bool) {
+	values := []any{}
+	rest := strings.TrimSpace(list)
+	for rest != "" {
+		m := valueRe.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, false
+		}
+		switch {
+		case m[2] != "":
+			values = append(values, json.Number(m[2]))
+		default:
+			values = append(values, strings.ReplaceAll(m[1], "''", "'"))
+		}
+		rest = strings.TrimSpace(rest[len(m[0]):])
+	}
+	return values, len(values) > 0
+}
+
+// unwrap remove the parentheses that wrap the whole value.
+func unwrap(v string) string {
+	for strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		depth := 0
+		for i, r := range v {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 && i < len(v)-1 {
+				return v
+			}
+		}
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+	return v
+}
@@ -0,0 +1,179 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	tb, err := s.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb.Columns[1].Nullable = true
+	tb.Constraints = append(tb.Constraints, &schema.Constraint{Name: "b_b2_check", Type: "CHECK", Def: "CHECK ((b2 = ANY (ARRAY['x'::text, 'y'::text])))"})
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+	f := "jsonschema_test_schema"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Fatal(err)
+	}
+	f := "jsonschema_test_a"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTableEnum(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Columns = append(ta.Columns, &schema.Column{Name: "a3", Type: s.Enums[0].Name})
+	o := New(c)
+	o.SetSchema(s)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Fatal(err)
+	}
+	f := "jsonschema_test_a_enum"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTypeProperty(t *testing.T) {
+	enums := []*schema.Enum{{Name: "public.post_types", Values: []string{"draft", "public"}}}
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{"integer", `{"type":"integer","minimum":-2147483648,"maximum":2147483647}`},
+		{"int(10) unsigned", `{"type":"integer","minimum":0,"maximum":4294967295}`},
+		{"tinyint(1)", `{"type":"boolean"}`},
+		{"numeric(10,2)", `{"type":"number"}`},
+		{"character varying(50)", `{"type":"string","maxLength":50}`},
+		{"nvarchar(max)", `{"type":"string"}`},
+		{"uuid", `{"type":"string","format":"uuid"}`},
+		{"timestamp(6) with time zone", `{"type":"string","format":"date-time"}`},
+		{"date", `{"type":"string","format":"date"}`},
+		{"bytea", `{"type":"string","contentEncoding":"base64"}`},
+		{"varchar(50)[]", `{"type":"array","items":{"type":"string","maxLength":50}}`},
+		{"enum('a','b')", `{"type":"string","enum":["a","b"]}`},
+		{"post_types", `{"type":"string","enum":["draft","public"]}`},
+		{"jsonb", `{}`},
+		{"geometry", `{}`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(typeProperty(tt.typ, enums))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != tt.want {
+			t.Errorf("%s: got %v\nwant %v", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestParseCheck(t *testing.T) {
+	tests := []struct {
+		def        string
+		wantColumn string
+		wantValues []any
+		wantOK     bool
+	}{
+		{"CHECK (status IN ('a', 'b'))", "status", []any{"a", "b"}, true},
+		{"CHECK ((`status` in (_utf8mb4'a',_utf8mb4'it''s')))", "status", []any{"a", "it's"}, true},
+		{"CHECK ((status = ANY (ARRAY['a'::text, 'b'::text])))", "status", []any{"a", "b"}, true},
+		{"CHECK (((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[])))", "status", []any{"a", "b"}, true},
+		{"([status]='a' OR [status]='b')", "status", []any{"a", "b"}, true},
+		{`CHECK ("level" IN (1, 2, 3))`, "level", []any{json.Number("1"), json.Number("2"), json.Number("3")}, true},
+		{"CHECK ((char_length((username)::text) > 4))", "", nil, false},
+		{"CHECK (a = 'x' OR b = 'y')", "", nil, false},
+	}
+	for _, tt := range tests {
+		column, values, ok := parseCheck(tt.def)
+		if ok != tt.wantOK {
+			t.Errorf("%s: got %v want %v", tt.def, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if column != tt.wantColumn {
+			t.Errorf("%s: got %v want %v", tt.def, column, tt.wantColumn)
+		}
+		if diff := cmp.Diff(values, tt.wantValues); diff != "" {
+			t.Errorf("%s: %s", tt.def, diff)
+		}
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	ddl "github.com/k1LoW/tbls/output/sql"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+// SupportFixDialects is the list of SQL dialects for the COMMENT statements of `tbls lint --fix-sql`.
+var SupportFixDialects = []string{"postgres", "mysql", "sqlserver"}
+
+// FixSQL struct.
+type FixSQL struct {
+	dialect string
+	comment string
+	ddl     *ddl.SQL
+}
+
+// NewFixSQL return FixSQL.
+func NewFixSQL(dialect, comment string) (*FixSQL, error) {
+	if !lo.Contains(SupportFixDialects, dialect) {
+		return nil, fmt.Errorf("unsupported SQL dialect '%s'", dialect)
+	}
+	d, err := ddl.New(nil, dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &FixSQL{
+		dialect: dialect,
+		comment: comment,
+		ddl:     d,
+	}, nil
+}
+
+// OutputFix output the SQL statements that set placeholder comments to the tables and columns reported by `requireTableComment` and `requireColumnComment`.
+func (f *FixSQL) OutputFix(wr io.Writer, s *schema.Schema, warns []config.RuleWarn) error {
+	g := f.ddl.Generator(s)
+	for _, w := range warns {
+		var stmt string
+		switch w.Rule {
+		case "requireTableComment":
+			t, err := s.FindTableByName(w.Table)
+			if err != nil {
+				return err
+			}
+			if stmt = g.Comment(t, nil, f.comment); stmt == "" {
+				stmt = fmt.Sprintf("-- unsupported in %s: comment on view %s", f.dialect, t.Name)
+			}
+		case "requireColumnComment":
+			t, err := s.FindTableByName(w.Table)
+			if err != nil {
+				return err
+			}
+			c, err := t.FindColumnByName(w.Column)
+			if err != nil {
+				return err
+			}
+			if stmt = g.Comment(t, c, f.comment); stmt == "" {
+				stmt = fmt.Sprintf("-- unsupported in %s: comment on view column %s.%s", f.dialect, t.Name, c.Name)
+			}
+		default:
+			continue
+		}
+		if _, err := fmt.Fprintln(wr, stmt); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputFix(t *testing.T) {
+	s := &schema.Schema{
+		Name: "testdb",
+		Tables: []*schema.Table{
+			{
+				Name: "public.users",
+				Type: "BASE TABLE",
+				Columns: []*schema.Column{
+					{Name: "id", Type: "bigint", ExtraDef: "auto_increment"},
+					{Name: "status", Type: "varchar(16)", Default: sql.NullString{String: "active", Valid: true}},
+					{Name: "nickname", Type: "text", Nullable: true},
+					{Name: "token", Type: "varchar(36)", Default: sql.NullString{String: "uuid()", Valid: true}, ExtraDef: "DEFAULT_GENERATED"},
+					{Name: "updated_at", Type: "datetime", Default: sql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}, ExtraDef: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+				},
+			},
+			{
+				Name: "user_names",
+				Type: "VIEW",
+				Columns: []*schema.Column{
+					{Name: "name", Type: "text", Nullable: true},
+				},
+			},
+		},
+	}
+	warns := []config.RuleWarn{
+		{Rule: "requireTableComment", Target: "public.users", Table: "public.users"},
+		{Rule: "requireColumnComment", Target: "public.users.id", Table: "public.users", Column: "id"},
+		{Rule: "requireColumnComment", Target: "public.users.status", Table: "public.users", Column: "status"},
+		{Rule: "requireColumnComment", Target: "public.users.nickname", Table: "public.users", Column: "nickname"},
+		{Rule: "requireColumnComment", Target: "public.users.token", Table: "public.users", Column: "token"},
+		{Rule: "requireColumnComment", Target: "public.users.updated_at", Table: "public.users", Column: "updated_at"},
+		{Rule: "requireTableComment", Target: "user_names", Table: "user_names"},
+		{Rule: "requireColumnComment", Target: "user_names.name", Table: "user_names", Column: "name"},
+		{Rule: "requireIndexComment", Target: "public.users.users_pkey", Table: "public.users", Index: "users_pkey"},
+	}
+	for _, dialect := range SupportFixDialects {
+		t.Run(dialect, func(t *testing.T) {
+			f, err := NewFixSQL(dialect, "TODO")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			if err := f.OutputFix(got, s, warns); err != nil {
+				t.Fatal(err)
+			}
+			fn := fmt.Sprintf("lint_fix_sql_%s", dialect)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), fn, got.String())
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), fn, got.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestNewFixSQL(t *testing.T) {
+	if _, err := NewFixSQL("sqlite", "TODO"); err == nil {
+		t.Error("want error")
+	}
+}
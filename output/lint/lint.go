@@ -0,0 +1,371 @@
+package lint
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/version"
+	"github.com/labstack/gommon/color"
+	"github.com/samber/lo"
+)
+
+// SupportFormats is the list of output formats for lint results.
+var SupportFormats = []string{"text", "json", "sarif", "junit", "checkstyle", "github"}
+
+// Lint struct.
+type Lint struct {
+	config *config.Config
+	format string
+}
+
+// New return Lint.
+func New(c *config.Config, format string) (*Lint, error) {
+	if format == "" {
+		format = "text"
+	}
+	if !lo.Contains(SupportFormats, format) {
+		return nil, fmt.Errorf("unsupported lint format '%s'", format)
+	}
+	return &Lint{
+		config: c,
+		format: format,
+	}, nil
+}
+
+// OutputWarns output the warnings of `tbls lint`.
+func (l *Lint) OutputWarns(wr io.Writer, warns []config.RuleWarn) error {
+	var err error
+	switch l.format {
+	case "text":
+		err = l.outputText(wr, warns)
+	case "json":
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(warns)
+	case "sarif":
+		encoder := json.NewEncoder(wr)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(l.sarif(warns))
+	case "junit":
+		err = writeXML(wr, junit(warns))
+	case "checkstyle":
+		err = writeXML(wr, l.checkstyle(warns))
+	case "github":
+		err = l.outputGitHub(wr, warns)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Path return the path of the document of the target of the warning.
+func (l *Lint) Path(w config.RuleWarn) string {
+	if w.Table != "" {
+		return filepath.ToSlash(filepath.Join(l.config.DocPath, fmt.Sprintf("%s.md", w.Table)))
+	}
+	return filepath.ToSlash(filepath.Join(l.config.DocPath, "README.md"))
+}
+
+func (l *Lint) outputText(wr io.Writer, warns []config.RuleWarn) error {
+	if len(warns) == 0 {
+		return nil
+	}
+	for _, w := range warns {
+		msg := fmt.Sprintf(": %s", w.Message)
+		if w.Severity != config.SeverityError {
+			msg = fmt.Sprintf("%s (%s)", msg, w.Severity)
+		}
+		if _, err := fmt.Fprintf(wr, "%s%s\n", color.Cyan(w.Target), color.White(msg, color.B)); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(wr, color.White(fmt.Sprintf("\n%d detected", len(warns)), color.B)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// outputGitHub output workflow commands of GitHub Actions to annotate the documents.
+func (l *Lint) outputGitHub(wr io.Writer, warns []config.RuleWarn) error {
+	for _, w := range warns {
+		if _, err := fmt.Fprintf(wr, "::%s file=%s,line=1,title=%s::%s\n",
+			githubLevel(w.Severity),
+			escapeGitHubProperty(l.Path(w)),
+			escapeGitHubProperty(fmt.Sprintf("tbls lint (%s)", w.Rule)),
+			escapeGitHubData(fmt.Sprintf("%s: %s", w.Target, w.Message)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubLevel(s config.Severity) string {
+	switch s {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "notice"
+	}
+	return "error"
+}
+
+func sarifLevel(s config.Severity) string {
+	switch s {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "note"
+	}
+	return "error"
+}
+
+var (
+	githubDataRep     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyRep = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeGitHubData(s string) string {
+	return githubDataRep.Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return githubPropertyRep.Replace(s)
+}
+
+// SARIF 2.1.0 ( https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html ).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+func (l *Lint) sarif(warns []config.RuleWarn) sarifLog {
+	rules := []sarifRule{}
+	results := []sarifResult{}
+	for _, w := range warns {
+		if !lo.ContainsBy(rules, func(r sarifRule) bool { return r.ID == w.Rule }) {
+			rules = append(rules, sarifRule{ID: w.Rule, ShortDescription: sarifMessage{Text: w.Rule}})
+		}
+		results = append(results, sarifResult{
+			RuleID:  w.Rule,
+			Level:   sarifLevel(w.Severity),
+			Message: sarifMessage{Text: fmt.Sprintf("%s: %s", w.Target, w.Message)},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: l.Path(w)},
+						Region:           sarifRegion{StartLine: 1},
+					},
+					LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: w.Target, Kind: kind(w)}},
+				},
+			},
+		})
+	}
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           version.Name,
+						Version:        version.Version,
+						InformationURI: "https://github.com/k1LoW/tbls",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+// kind return the kind of the target object of the warning.
+func kind(w config.RuleWarn) string {
+	switch {
+	case w.Column != "":
+		return "column"
+	case w.Index != "":
+		return "index"
+	case w.Constraint != "":
+		return "constraint"
+	case w.Trigger != "":
+		return "trigger"
+	case w.Table != "":
+		return "table"
+	}
+	return ""
+}
+
+// JUnit XML.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func junit(warns []config.RuleWarn) junitTestSuites {
+	suites := []junitTestSuite{}
+	for _, w := range warns {
+		i := lo.IndexOf(lo.Map(suites, func(s junitTestSuite, _ int) string { return s.Name }), w.Rule)
+		if i < 0 {
+			suites = append(suites, junitTestSuite{Name: w.Rule})
+			i = len(suites) - 1
+		}
+		suites[i].Tests++
+		suites[i].Failures++
+		suites[i].TestCases = append(suites[i].TestCases, junitTestCase{
+			Name:      w.Target,
+			ClassName: w.Rule,
+			Failure: junitFailure{
+				Message: w.Message,
+				Type:    string(w.Severity),
+				Text:    fmt.Sprintf("%s: %s", w.Target, w.Message),
+			},
+		})
+	}
+	return junitTestSuites{
+		Name:       "tbls lint",
+		Tests:      len(warns),
+		Failures:   len(warns),
+		TestSuites: suites,
+	}
+}
+
+// Checkstyle XML.
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (l *Lint) checkstyle(warns []config.RuleWarn) checkstyleResult {
+	files := []checkstyleFile{}
+	for _, w := range warns {
+		p := l.Path(w)
+		i := lo.IndexOf(lo.Map(files, func(f checkstyleFile, _ int) string { return f.Name }), p)
+		if i < 0 {
+			files = append(files, checkstyleFile{Name: p})
+			i = len(files) - 1
+		}
+		files[i].Errors = append(files[i].Errors, checkstyleError{
+			Line:     1,
+			Severity: string(w.Severity),
+			Message:  fmt.Sprintf("%s: %s", w.Target, w.Message),
+			Source:   fmt.Sprintf("tbls.lint.%s", w.Rule),
+		})
+	}
+	return checkstyleResult{
+		Version: "4.3",
+		Files:   files,
+	}
+}
+
+func writeXML(wr io.Writer, v interface{}) error {
+	if _, err := fmt.Fprint(wr, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(wr)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(wr)
+	return err
+}
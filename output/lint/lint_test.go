@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/version"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputWarns(t *testing.T) {
+	v := version.Version
+	version.Version = "0.0.0"
+	t.Cleanup(func() {
+		version.Version = v
+	})
+	warns := []config.RuleWarn{
+		{Rule: "requireTableComment", Severity: config.SeverityError, Target: "users", Table: "users", Message: "table comment required."},
+		{Rule: "requireColumnComment", Severity: config.SeverityError, Target: "users.email", Table: "users", Column: "email", Message: "column comment required."},
+		{Rule: "requireIndexComment", Severity: config.SeverityWarning, Target: "posts.posts_user_id_idx", Table: "posts", Index: "posts_user_id_idx", Message: "index comment required."},
+		{Rule: "unrelatedTable", Severity: config.SeverityInfo, Target: "testdb", Message: "unrelated (isolated) table exists. [logs, <tmp>]"},
+	}
+	for _, format := range SupportFormats {
+		if format == "text" {
+			continue
+		}
+		t.Run(format, func(t *testing.T) {
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.DocPath = "dbdoc"
+			o, err := New(c, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			if err := o.OutputWarns(got, warns); err != nil {
+				t.Fatal(err)
+			}
+			f := fmt.Sprintf("lint_output_%s", format)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), f, got.String())
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(c, ""); err != nil {
+		t.Errorf("got error: %s", err)
+	}
+	if _, err := New(c, "html"); err == nil {
+		t.Error("want error")
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
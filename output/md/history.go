@@ -0,0 +1,104 @@
+package md
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/history"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/output/diff"
+)
+
+// HistoryFileName is the file name of the schema history document.
+const HistoryFileName = "history.md"
+
+var anchorRe = regexp.MustCompile(`[^\p{L}\p{N}_\- ]`)
+
+// OutputHistory output md format for schema history.
+func (m *Md) OutputHistory(wr io.Writer, h *history.History) error {
+	ts, err := m.historyTemplate()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmpl := template.Must(template.New("history").Funcs(output.Funcs(&m.config.MergedDict)).Parse(ts))
+	templateData := m.makeHistoryTemplateData(h)
+	if err := tmpl.Execute(wr, templateData); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// HistoryAnchor return the anchor of the table section in the schema history document.
+func HistoryAnchor(table string) string {
+	return strings.ReplaceAll(anchorRe.ReplaceAllString(strings.ToLower(table), ""), " ", "-")
+}
+
+func (m *Md) historyTemplate() (string, error) {
+	if m.config.Templates.MD.History != "" {
+		tb, err := os.ReadFile(m.config.Templates.MD.History)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return string(tb), nil
+	}
+	tb, err := m.tmpl.ReadFile("templates/history.md.tmpl")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(tb), nil
+}
+
+func (m *Md) makeHistoryTemplateData(h *history.History) map[string]interface{} {
+	adjust := m.config.Format.Adjust
+
+	tablesData := []map[string]interface{}{}
+	for _, th := range h.Tables() {
+		data := [][]string{
+			{
+				m.config.MergedDict.Lookup("Date"),
+				m.config.MergedDict.Lookup("Commit"),
+				m.config.MergedDict.Lookup("Author"),
+				m.config.MergedDict.Lookup("Change"),
+			},
+			{"----", "------", "------", "------"},
+		}
+		for _, ev := range th.Events {
+			data = append(data, []string{
+				ev.Entry.Date.Format("2006-01-02"),
+				ev.Entry.ShortCommit(),
+				mdEscRep.Replace(ev.Entry.Author),
+				mdEscRep.Replace(diff.Text(ev.Change)),
+			})
+		}
+		if adjust {
+			data = adjustTable(data)
+		}
+		tablesData = append(tablesData, map[string]interface{}{
+			"Name":   th.Name,
+			"Events": data,
+		})
+	}
+
+	entriesData := []map[string]interface{}{}
+	for _, e := range h.Entries {
+		changes := []string{}
+		for _, c := range e.Changes {
+			changes = append(changes, diff.Text(c))
+		}
+		entriesData = append(entriesData, map[string]interface{}{
+			"Title":   fmt.Sprintf("%s %s %s", e.Date.Format("2006-01-02"), e.ShortCommit(), e.Subject),
+			"Author":  e.Author,
+			"Changes": changes,
+		})
+	}
+
+	return map[string]interface{}{
+		"Tables":  tablesData,
+		"Entries": entriesData,
+	}
+}
@@ -0,0 +1,98 @@
+package md
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/history"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputHistory(t *testing.T) {
+	h := &history.History{
+		Entries: []*history.Entry{
+			{
+				Commit:  "2222222222",
+				Author:  "bob",
+				Date:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Subject: "Add a3",
+				Changes: schema.Changes{
+					{Action: schema.ChangeActionAdded, Class: schema.ChangeClassAdditive, Object: schema.ChangeObjectColumn, Table: "a", Name: "a3", To: "TEXT"},
+					{Action: schema.ChangeActionModified, Class: schema.ChangeClassBreaking, Object: schema.ChangeObjectColumn, Table: "b", Name: "b2", Attribute: "type", From: "TEXT", To: "INTEGER"},
+				},
+			},
+			{
+				Commit:  "1111111111",
+				Author:  "alice",
+				Date:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Subject: "Initial",
+				Changes: schema.Changes{
+					{Action: schema.ChangeActionAdded, Class: schema.ChangeClassAdditive, Object: schema.ChangeObjectTable, Table: "a", Name: "a", To: "BASE TABLE"},
+					{Action: schema.ChangeActionAdded, Class: schema.ChangeClassAdditive, Object: schema.ChangeObjectTable, Table: "b", Name: "b", To: "BASE TABLE"},
+				},
+			},
+		},
+	}
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(""); err != nil {
+		t.Fatal(err)
+	}
+	got := &bytes.Buffer{}
+	if err := New(c).OutputHistory(got, h); err != nil {
+		t.Fatal(err)
+	}
+	f := "md_test_history.md"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got)
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTableWithHistory(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(""); err != nil {
+		t.Fatal(err)
+	}
+	c.Format.History = true
+	got := &bytes.Buffer{}
+	if err := New(c).OutputTable(got, s.Tables[0]); err != nil {
+		t.Fatal(err)
+	}
+	want := "[History](history.md#a)"
+	if !strings.Contains(got.String(), want) {
+		t.Errorf("got %v\nwant %v", got.String(), want)
+	}
+}
+
+func TestHistoryAnchor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"users", "users"},
+		{"public.users", "publicusers"},
+		{"Space Table", "space-table"},
+		{"user_options", "user_options"},
+	}
+	for _, tt := range tests {
+		if got := HistoryAnchor(tt.in); got != tt.want {
+			t.Errorf("got %v\nwant %v", got, tt.want)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/k1LoW/errors"
 	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/coverage"
 	"github.com/k1LoW/tbls/output"
 	"github.com/k1LoW/tbls/output/mermaid"
 	"github.com/k1LoW/tbls/schema"
@@ -56,6 +57,14 @@ func (m *Md) OutputSchema(wr io.Writer, s *schema.Schema) error {
 	templateData := m.makeSchemaTemplateData(s)
 	templateData["er"] = !m.config.ER.Skip
 	templateData["showOnlyFirstParagraph"] = m.config.Format.ShowOnlyFirstParagraph
+	templateData["erViewer"] = ""
+	if m.config.ER.Viewer {
+		templateData["erViewer"] = fmt.Sprintf("%s%s", m.config.BaseURL, config.ERViewerFileName)
+	}
+	templateData["coverageTrend"] = ""
+	if m.config.Format.CoverageTrend {
+		templateData["coverageTrend"] = fmt.Sprintf("![coverage trend](%s%s)", m.config.BaseURL, coverage.TrendFileName)
+	}
 	switch m.config.ER.Format {
 	case "mermaid":
 		buf := new(bytes.Buffer)
@@ -93,6 +102,9 @@ func (m *Md) OutputTable(wr io.Writer, t *schema.Table) error {
 	default:
 		templateData["erDiagram"] = fmt.Sprintf("![er](%s%s.%s)", m.config.BaseURL, mdurl.Encode(t.Name), m.config.ER.Format)
 	}
+	if m.config.Format.History {
+		templateData["history"] = fmt.Sprintf("%s%s#%s", m.config.BaseURL, HistoryFileName, HistoryAnchor(t.Name))
+	}
 
 	if err := tmpl.Execute(wr, templateData); err != nil {
 		return errors.WithStack(err)
@@ -0,0 +1,869 @@
+package prisma
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+var _ output.Output = &Prisma{}
+
+// ignoredComment is the comment of the models that Prisma Client can not handle (same as `prisma db pull`).
+const ignoredComment = "The underlying table does not contain a valid unique identifier and can therefore currently not be handled by Prisma Client."
+
+var (
+	identRe             = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	invalidCharRe       = regexp.MustCompile(`[^A-Za-z0-9_]`)
+	typeArgsRe          = regexp.MustCompile(`^([^(]+)\(([^)]*)\)(.*)$`)
+	enumTypeRe          = regexp.MustCompile(`^(?i)(?:enum|set)\s*\((.*)\)$`)
+	enumValueRe         = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	numberRe            = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+	stringRe            = regexp.MustCompile(`^[Nn]?'((?:[^']|'')*)'$`)
+	castRe              = regexp.MustCompile(`^(.+?)(::[a-zA-Z_][a-zA-Z0-9_ ."]*(\([0-9, ]*\))?(\[\])?)+$`)
+	nowRe               = regexp.MustCompile(`(?i)^(current_timestamp|now|getdate|getutcdate|sysdatetime|sysutcdatetime|localtimestamp)\s*(\([0-9]*\))?$`)
+	nextvalRe           = regexp.MustCompile(`(?i)^nextval\(`)
+	referentialActionRe = regexp.MustCompile(`(?i)ON\s+(DELETE|UPDATE)\s+(CASCADE|SET\s+NULL|SET\s+DEFAULT|RESTRICT|NO\s+ACTION)`)
+)
+
+// providers is the providers of datasource of the drivers.
+var providers = map[string]string{
+	"postgres":  "postgresql",
+	"redshift":  "postgresql",
+	"mysql":     "mysql",
+	"mariadb":   "mysql",
+	"sqlite":    "sqlite",
+	"sqlserver": "sqlserver",
+}
+
+// defaultSchemas is the schema of the tables whose names are not qualified.
+var defaultSchemas = map[string]string{
+	"postgresql": "public",
+	"sqlserver":  "dbo",
+}
+
+// scalarTypes is the scalar types of Prisma of the column types.
+var scalarTypes = map[string]string{
+	"bool":                        "Boolean",
+	"boolean":                     "Boolean",
+	"bit":                         "Boolean",
+	"tinyint":                     "Int",
+	"smallint":                    "Int",
+	"mediumint":                   "Int",
+	"int":                         "Int",
+	"integer":                     "Int",
+	"int2":                        "Int",
+	"int4":                        "Int",
+	"serial":                      "Int",
+	"smallserial":                 "Int",
+	"serial2":                     "Int",
+	"serial4":                     "Int",
+	"bigint":                      "BigInt",
+	"int8":                        "BigInt",
+	"bigserial":                   "BigInt",
+	"serial8":                     "BigInt",
+	"real":                        "Float",
+	"float":                       "Float",
+	"float4":                      "Float",
+	"float8":                      "Float",
+	"double":                      "Float",
+	"double precision":            "Float",
+	"decimal":                     "Decimal",
+	"numeric":                     "Decimal",
+	"money":                       "Decimal",
+	"smallmoney":                  "Decimal",
+	"char":                        "String",
+	"character":                   "String",
+	"bpchar":                      "String",
+	"nchar":                       "String",
+	"varchar":                     "String",
+	"character varying":           "String",
+	"nvarchar":                    "String",
+	"text":                        "String",
+	"tinytext":                    "String",
+	"mediumtext":                  "String",
+	"longtext":                    "String",
+	"ntext":                       "String",
+	"citext":                      "String",
+	"uuid":                        "String",
+	"uniqueidentifier":            "String",
+	"date":                        "DateTime",
+	"time":                        "DateTime",
+	"time without time zone":      "DateTime",
+	"time with time zone":         "DateTime",
+	"timetz":                      "DateTime",
+	"timestamp":                   "DateTime",
+	"timestamp without time zone": "DateTime",
+	"timestamp with time zone":    "DateTime",
+	"timestamptz":                 "DateTime",
+	"datetime":                    "DateTime",
+	"datetime2":                   "DateTime",
+	"smalldatetime":               "DateTime",
+	"datetimeoffset":              "DateTime",
+	"json":                        "Json",
+	"jsonb":                       "Json",
+	"bytea":                       "Bytes",
+	"blob":                        "Bytes",
+	"tinyblob":                    "Bytes",
+	"mediumblob":                  "Bytes",
+	"longblob":                    "Bytes",
+	"binary":                      "Bytes",
+	"varbinary":                   "Bytes",
+	"image":                       "Bytes",
+}
+
+// reservedNames is the names that can not be used for models and enums.
+var reservedNames = []string{
+	"String", "Boolean", "Int", "BigInt", "Float", "Decimal", "DateTime", "Json", "Bytes", "Unsupported",
+	"model", "enum", "type", "view", "generator", "datasource",
+}
+
+// Prisma struct.
+type Prisma struct {
+	config *config.Config
+}
+
+// New return Prisma.
+func New(c *config.Config) *Prisma {
+	return &Prisma{
+		config: c,
+	}
+}
+
+// OutputSchema output Prisma schema for full relation.
+func (p *Prisma) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	b := newBuilder(s.Driver, s.Enums)
+	b.build(s.Tables, s.Relations)
+	return b.write(wr)
+}
+
+// OutputTable output Prisma schema for table.
+func (p *Prisma) OutputTable(wr io.Writer, t *schema.Table) error {
+	tables, relations, err := t.CollectTablesAndRelations(*p.config.ER.Distance, true)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	b := newBuilder(nil, nil)
+	b.build(tables, relations)
+	return b.write(wr)
+}
+
+// OutputFunction output Prisma schema for function (not supported).
+func (p *Prisma) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// Prisma schema does not support functions
+	return nil
+}
+
+type model struct {
+	table   *schema.Table
+	name    string
+	dbName  string
+	schema  string
+	comment string
+	fields  []*field
+	attrs   []string
+	ignored bool
+	names   map[string]struct{}
+	columns map[string]*field
+}
+
+type field struct {
+	comment string
+	name    string
+	typ     string
+	attrs   []string
+	enum    *enum
+	scalar  string
+}
+
+type enum struct {
+	name   string
+	dbName string
+	schema string
+	values []string
+}
+
+// builder builds the models and the enums of Prisma schema.
+type builder struct {
+	provider      string
+	defaultSchema string
+	enumList      []*schema.Enum
+	models        []*model
+	tableModels   map[*schema.Table]*model
+	enums         []*enum
+	// names is the names of models and enums. They share the namespace.
+	names map[string]struct{}
+}
+
+func newBuilder(d *schema.Driver, enums []*schema.Enum) *builder {
+	b := &builder{
+		provider:    "postgresql",
+		enumList:    enums,
+		tableModels: map[*schema.Table]*model{},
+		names:       map[string]struct{}{},
+	}
+	if d != nil {
+		if p, ok := providers[d.Name]; ok {
+			b.provider = p
+		}
+		if d.Meta != nil && d.Meta.CurrentSchema != "" {
+			b.defaultSchema = d.Meta.CurrentSchema
+		}
+	}
+	if b.defaultSchema == "" {
+		b.defaultSchema = defaultSchemas[b.provider]
+	}
+	return b
+}
+
+func (b *builder) build(tables []*schema.Table, relations []*schema.Relation) {
+	tables = lo.Filter(tables, func(t *schema.Table, _ int) bool {
+		return !strings.Contains(strings.ToUpper(t.Type), "VIEW")
+	})
+	// the names of the tables in the different schemas are qualified only when they conflict
+	counts := map[string]int{}
+	for _, t := range tables {
+		_, tn := b.splitName(t.Name)
+		counts[sanitize(tn, "model")]++
+	}
+	for _, t := range tables {
+		sn, tn := b.splitName(t.Name)
+		name := sanitize(tn, "model")
+		if counts[name] > 1 && sn != "" {
+			name = sanitize(fmt.Sprintf("%s_%s", sn, tn), "model")
+		}
+		m := &model{
+			table:   t,
+			name:    uniqueName(b.names, rename(name)),
+			dbName:  tn,
+			schema:  sn,
+			comment: t.Comment,
+			names:   map[string]struct{}{},
+			columns: map[string]*field{},
+		}
+		b.models = append(b.models, m)
+		b.tableModels[t] = m
+	}
+	for _, e := range b.enumList {
+		b.findEnum(e.Name)
+	}
+	for _, m := range b.models {
+		b.addColumns(m)
+	}
+	for _, r := range relations {
+		b.addRelation(r, relations)
+	}
+	for _, m := range b.models {
+		b.addBlockAttributes(m)
+	}
+}
+
+// splitName split the table name into the schema name and the table name.
+func (b *builder) splitName(name string) (string, string) {
+	if _, ok := defaultSchemas[b.provider]; !ok || !strings.Contains(name, ".") {
+		return b.defaultSchema, name
+	}
+	s := strings.SplitN(name, ".", 2)
+	return s[0], s[1]
+}
+
+// multiSchema return whether the models are in the multiple schemas.
+func (b *builder) multiSchema() bool {
+	return len(b.schemas()) > 1
+}
+
+func (b *builder) schemas() []string {
+	schemas := []string{}
+	for _, m := range b.models {
+		if m.schema != "" && !lo.Contains(schemas, m.schema) {
+			schemas = append(schemas, m.schema)
+		}
+	}
+	for _, e := range b.enums {
+		if e.schema != "" && !lo.Contains(schemas, e.schema) {
+			schemas = append(schemas, e.schema)
+		}
+	}
+	sort.Strings(schemas)
+	return schemas
+}
+
+func (b *builder) addColumns(m *model) {
+	t := m.table
+	pks := t.PrimaryKeyColumns()
+	for _, c := range t.Columns {
+		f := &field{
+			comment: c.Comment,
+			name:    uniqueName(m.names, sanitize(c.Name, "field")),
+		}
+		m.columns[c.Name] = f
+		list := b.fieldType(m, c, f)
+		switch {
+		case list:
+			f.typ += "[]"
+		case c.Nullable:
+			f.typ += "?"
+		}
+		if len(pks) == 1 && pks[0] == c.Name {
+			f.attrs = append(f.attrs, "@id")
+		}
+		if v, ok := b.defaultValue(c, f); ok {
+			f.attrs = append(f.attrs, fmt.Sprintf("@default(%s)", v))
+		}
+		if len(pks) != 1 || pks[0] != c.Name {
+			if uniques := uniqueColumns(t); lo.ContainsBy(uniques, func(u []string) bool { return len(u) == 1 && u[0] == c.Name }) {
+				f.attrs = append(f.attrs, "@unique")
+			}
+		}
+		if f.name != c.Name {
+			f.attrs = append(f.attrs, fmt.Sprintf("@map(%s)", quote(c.Name)))
+		}
+		if f.enum == nil && !strings.HasPrefix(f.typ, "Unsupported") {
+			if native := b.nativeType(c); native != "" {
+				f.attrs = append(f.attrs, native)
+			}
+		}
+		m.fields = append(m.fields, f)
+	}
+}
+
+// fieldType set the type of the field and return whether the type is a list.
+func (b *builder) fieldType(m *model, c *schema.Column, f *field) bool {
+	typ := strings.TrimSpace(c.Type)
+	list := false
+	if strings.HasSuffix(typ, "[]") && b.provider == "postgresql" {
+		typ = strings.TrimSuffix(typ, "[]")
+		list = true
+	}
+	if enumTypeRe.MatchString(typ) {
+		// the enum of MySQL is defined per column (same as `prisma db pull`)
+		e := &enum{
+			name:   uniqueName(b.names, sanitize(fmt.Sprintf("%s_%s", m.name, f.name), "enum")),
+			values: enumValues(typ),
+		}
+		e.dbName = e.name
+		b.enums = append(b.enums, e)
+		f.enum = e
+		f.typ = e.name
+		return list
+	}
+	if e := b.findEnum(typ); e != nil {
+		f.enum = e
+		f.typ = e.name
+		return list
+	}
+	name, args := parseType(typ)
+	scalar, ok := scalarTypes[name]
+	switch {
+	case !ok:
+		f.typ = fmt.Sprintf("Unsupported(%s)", quote(typ))
+		return list
+	case name == "tinyint" && len(args) == 1 && args[0] == "1":
+		// tinyint(1) is the boolean of MySQL
+		scalar = "Boolean"
+	case name == "bit" && len(args) == 1 && args[0] != "1":
+		scalar = "Bytes"
+	}
+	f.typ = scalar
+	f.scalar = scalar
+	return list
+}
+
+// findEnum return the enum of the type. The enum is created from the enums of the schema at the first time.
+func (b *builder) findEnum(typ string) *enum {
+	for _, se := range b.enumList {
+		sn, en := b.splitName(se.Name)
+		if !strings.EqualFold(se.Name, typ) && !strings.EqualFold(en, typ) {
+			continue
+		}
+		for _, e := range b.enums {
+			if e.dbName == en && e.schema == sn {
+				return e
+			}
+		}
+		e := &enum{
+			name:   uniqueName(b.names, rename(sanitize(en, "enum"))),
+			dbName: en,
+			schema: sn,
+			values: se.Values,
+		}
+		b.enums = append(b.enums, e)
+		return e
+	}
+	return nil
+}
+
+// nativeType return the native type attribute of the column. e.g. `@db.VarChar(255)`.
+func (b *builder) nativeType(c *schema.Column) string {
+	if b.provider == "sqlite" {
+		return ""
+	}
+	name, args := parseType(strings.TrimSuffix(strings.TrimSpace(c.Type), "[]"))
+	sized := func(native string) string {
+		if len(args) == 0 {
+			return ""
+		}
+		if len(args) == 1 && strings.EqualFold(args[0], "max") {
+			return fmt.Sprintf("@db.%s(Max)", native)
+		}
+		return fmt.Sprintf("@db.%s(%s)", native, strings.Join(args, ", "))
+	}
+	switch name {
+	case "varchar", "character varying":
+		return sized("VarChar")
+	case "char", "character", "bpchar":
+		return sized("Char")
+	case "decimal", "numeric":
+		return sized("Decimal")
+	case "date":
+		return "@db.Date"
+	}
+	switch {
+	case b.provider == "sqlserver" && name == "nvarchar":
+		return sized("NVarChar")
+	case b.provider == "sqlserver" && name == "nchar":
+		return sized("NChar")
+	case b.provider == "postgresql" && name == "uuid":
+		return "@db.Uuid"
+	case b.provider == "mysql" && name == "text":
+		return "@db.Text"
+	}
+	return ""
+}
+
+// defaultValue return the argument of `@default()`.
+func (b *builder) defaultValue(c *schema.Column, f *field) (string, bool) {
+	if isAutoIncrement(c) {
+		return "autoincrement()", true
+	}
+	if !c.Default.Valid {
+		return "", false
+	}
+	v := unwrap(strings.TrimSpace(c.Default.String))
+	if m := castRe.FindStringSubmatch(v); m != nil {
+		v = unwrap(m[1])
+	}
+	if b.provider == "mysql" && !strings.Contains(strings.ToUpper(c.ExtraDef), "DEFAULT_GENERATED") &&
+		(f.enum != nil || f.scalar == "String") && !stringRe.MatchString(v) && !strings.EqualFold(v, "NULL") {
+		// the default values of strings of MySQL are not quoted
+		v = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
+	}
+	str := ""
+	isString := false
+	if m := stringRe.FindStringSubmatch(v); m != nil {
+		str = strings.ReplaceAll(m[1], "''", "'")
+		isString = true
+	}
+	switch {
+	case strings.EqualFold(v, "NULL"):
+		return "", false
+	case f.enum != nil && isString:
+		return sanitize(str, "value"), true
+	case f.scalar == "Boolean" && (strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "b'1'")):
+		return "true", true
+	case f.scalar == "Boolean" && (strings.EqualFold(v, "false") || v == "0" || strings.EqualFold(v, "b'0'")):
+		return "false", true
+	case (f.scalar == "Int" || f.scalar == "BigInt" || f.scalar == "Float" || f.scalar == "Decimal") && numberRe.MatchString(v):
+		return v, true
+	case f.scalar == "String" && isString:
+		return quote(str), true
+	case f.scalar == "DateTime" && nowRe.MatchString(v):
+		return "now()", true
+	}
+	return fmt.Sprintf("dbgenerated(%s)", quote(strings.TrimSpace(c.Default.String))), true
+}
+
+// addRelation add the relation fields to the both sides of the relation.
+func (b *builder) addRelation(r *schema.Relation, relations []*schema.Relation) {
+	child, ok := b.tableModels[r.Table]
+	if !ok {
+		return
+	}
+	parent, ok := b.tableModels[r.ParentTable]
+	if !ok {
+		return
+	}
+	fields := []string{}
+	optional := false
+	for _, c := range r.Columns {
+		f, ok := child.columns[c.Name]
+		if !ok {
+			return
+		}
+		fields = append(fields, f.name)
+		optional = optional || c.Nullable
+	}
+	// the referenced fields must be unique in Prisma
+	parentColumns := lo.Map(r.ParentColumns, func(c *schema.Column, _ int) string { return c.Name })
+	if !lo.ContainsBy(uniqueColumns(r.ParentTable), func(u []string) bool { return len(u) == len(parentColumns) && lo.Every(u, parentColumns) }) {
+		return
+	}
+	references := []string{}
+	for _, c := range r.ParentColumns {
+		f, ok := parent.columns[c.Name]
+		if !ok {
+			return
+		}
+		references = append(references, f.name)
+	}
+	relName := fmt.Sprintf("%s_%sTo%s", child.name, strings.Join(fields, "_"), parent.name)
+	// the relations must be named when the models have the multiple relations
+	named := r.Table == r.ParentTable || len(lo.Filter(relations, func(rr *schema.Relation, _ int) bool {
+		_, ok1 := b.tableModels[rr.Table]
+		_, ok2 := b.tableModels[rr.ParentTable]
+		return ok1 && ok2 && ((rr.Table == r.Table && rr.ParentTable == r.ParentTable) || (rr.Table == r.ParentTable && rr.ParentTable == r.Table))
+	})) > 1
+
+	args := []string{}
+	if named {
+		args = append(args, quote(relName))
+	}
+	args = append(args, fmt.Sprintf("fields: [%s]", strings.Join(fields, ", ")), fmt.Sprintf("references: [%s]", strings.Join(references, ", ")))
+	for _, m := range referentialActionRe.FindAllStringSubmatch(r.Def, -1) {
+		args = append(args, fmt.Sprintf("on%s: %s", pascal(m[1]), referentialAction(m[2])))
+	}
+	typ := parent.name
+	if optional {
+		typ += "?"
+	}
+	cf := &field{
+		name:  relationFieldName(child, parent.name, relName),
+		typ:   typ,
+		attrs: []string{fmt.Sprintf("@relation(%s)", strings.Join(args, ", "))},
+	}
+	if !hasUniqueIdentifier(parent.table) {
+		cf.attrs = append(cf.attrs, "@ignore")
+	}
+	child.fields = append(child.fields, cf)
+
+	typ = child.name + "[]"
+	if lo.ContainsBy(uniqueColumns(r.Table), func(u []string) bool {
+		return len(u) == len(r.Columns) && lo.Every(u, lo.Map(r.Columns, func(c *schema.Column, _ int) string { return c.Name }))
+	}) {
+		// one-to-one relation
+		typ = child.name + "?"
+	}
+	pf := &field{
+		name: relationFieldName(parent, child.name, relName),
+		typ:  typ,
+	}
+	if named {
+		pf.attrs = append(pf.attrs, fmt.Sprintf("@relation(%s)", quote(relName)))
+	}
+	if !hasUniqueIdentifier(child.table) {
+		pf.attrs = append(pf.attrs, "@ignore")
+	}
+	parent.fields = append(parent.fields, pf)
+}
+
+func (b *builder) addBlockAttributes(m *model) {
+	t := m.table
+	pks := t.PrimaryKeyColumns()
+	fieldNames := func(columns []string) (string, bool) {
+		names := []string{}
+		for _, c := range columns {
+			f, ok := m.columns[c]
+			if !ok {
+				return "", false
+			}
+			names = append(names, f.name)
+		}
+		return strings.Join(names, ", "), true
+	}
+	if len(pks) > 1 {
+		if names, ok := fieldNames(pks); ok {
+			m.attrs = append(m.attrs, fmt.Sprintf("@@id([%s])", names))
+		}
+	}
+	for _, u := range uniqueColumns(t) {
+		if len(u) < 2 || (len(pks) > 1 && lo.Every(pks, u) && len(pks) == len(u)) {
+			continue
+		}
+		if names, ok := fieldNames(u); ok {
+			m.attrs = append(m.attrs, fmt.Sprintf("@@unique([%s])", names))
+		}
+	}
+	indexed := [][]string{}
+	for _, i := range t.Indexes {
+		if len(i.Columns) == 0 || isUniqueIndex(t, i) || lo.ContainsBy(indexed, func(cols []string) bool { return strings.Join(cols, ",") == strings.Join(i.Columns, ",") }) {
+			continue
+		}
+		indexed = append(indexed, i.Columns)
+		if names, ok := fieldNames(i.Columns); ok {
+			m.attrs = append(m.attrs, fmt.Sprintf("@@index([%s])", names))
+		}
+	}
+	if m.name != m.dbName {
+		m.attrs = append(m.attrs, fmt.Sprintf("@@map(%s)", quote(m.dbName)))
+	}
+	if b.multiSchema() {
+		m.attrs = append(m.attrs, fmt.Sprintf("@@schema(%s)", quote(m.schema)))
+	}
+	if !hasUniqueIdentifier(t) {
+		m.ignored = true
+		m.attrs = append(m.attrs, "@@ignore")
+	}
+}
+
+func (b *builder) write(wr io.Writer) error {
+	w := &strings.Builder{}
+	w.WriteString("generator client {\n  provider = \"prisma-client-js\"\n}\n\n")
+	w.WriteString("datasource db {\n")
+	settings := [][2]string{
+		{"provider", quote(b.provider)},
+		{"url", `env("DATABASE_URL")`},
+	}
+	if b.multiSchema() {
+		settings = append(settings, [2]string{"schemas", fmt.Sprintf("[%s]", strings.Join(lo.Map(b.schemas(), func(s string, _ int) string { return quote(s) }), ", "))})
+	}
+	width := lo.Max(lo.Map(settings, func(s [2]string, _ int) int { return len(s[0]) }))
+	for _, s := range settings {
+		fmt.Fprintf(w, "  %-*s = %s\n", width, s[0], s[1])
+	}
+	w.WriteString("}\n")
+	for _, m := range b.models {
+		w.WriteString("\n")
+		writeModel(w, m)
+	}
+	for _, e := range b.enums {
+		w.WriteString("\n")
+		b.writeEnum(w, e)
+	}
+	if _, err := io.WriteString(wr, w.String()); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func writeModel(w *strings.Builder, m *model) {
+	comments := []string{}
+	if m.comment != "" {
+		comments = append(comments, m.comment)
+	}
+	if m.ignored {
+		comments = append(comments, ignoredComment)
+	}
+	writeComment(w, "", strings.Join(comments, "\n"))
+	fmt.Fprintf(w, "model %s {\n", m.name)
+	nameWidth := lo.Max(lo.Map(m.fields, func(f *field, _ int) int { return len(f.name) }))
+	typeWidth := lo.Max(lo.Map(m.fields, func(f *field, _ int) int { return len(f.typ) }))
+	for _, f := range m.fields {
+		writeComment(w, "  ", f.comment)
+		line := fmt.Sprintf("  %-*s %-*s %s", nameWidth, f.name, typeWidth, f.typ, strings.Join(f.attrs, " "))
+		fmt.Fprintf(w, "%s\n", strings.TrimRight(line, " "))
+	}
+	if len(m.attrs) > 0 {
+		if len(m.fields) > 0 {
+			w.WriteString("\n")
+		}
+		for _, a := range m.attrs {
+			fmt.Fprintf(w, "  %s\n", a)
+		}
+	}
+	w.WriteString("}\n")
+}
+
+func (b *builder) writeEnum(w *strings.Builder, e *enum) {
+	fmt.Fprintf(w, "enum %s {\n", e.name)
+	for _, v := range e.values {
+		name := sanitize(v, "value")
+		if name != v {
+			fmt.Fprintf(w, "  %s @map(%s)\n", name, quote(v))
+			continue
+		}
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	attrs := []string{}
+	if e.name != e.dbName {
+		attrs = append(attrs, fmt.Sprintf("@@map(%s)", quote(e.dbName)))
+	}
+	if b.multiSchema() {
+		attrs = append(attrs, fmt.Sprintf("@@schema(%s)", quote(e.schema)))
+	}
+	if len(attrs) > 0 {
+		w.WriteString("\n")
+		for _, a := range attrs {
+			fmt.Fprintf(w, "  %s\n", a)
+		}
+	}
+	w.WriteString("}\n")
+}
+
+func writeComment(w *strings.Builder, indent, comment string) {
+	if comment == "" {
+		return
+	}
+	for _, l := range strings.Split(comment, "\n") {
+		fmt.Fprintf(w, "%s/// %s\n", indent, strings.TrimRight(l, "\r"))
+	}
+}
+
+// relationFieldName return the name of the relation field. The name of the relation is used when the name of the model conflicts.
+func relationFieldName(m *model, name, relName string) string {
+	if _, ok := m.names[name]; !ok {
+		return uniqueName(m.names, name)
+	}
+	return uniqueName(m.names, fmt.Sprintf("%s_%s", name, relName))
+}
+
+// uniqueColumns return the columns of the primary key, the unique constraints and the unique indexes.
+func uniqueColumns(t *schema.Table) [][]string {
+	uniques := [][]string{}
+	add := func(columns []string) {
+		if len(columns) == 0 || lo.ContainsBy(uniques, func(u []string) bool { return strings.Join(u, ",") == strings.Join(columns, ",") }) {
+			return
+		}
+		uniques = append(uniques, columns)
+	}
+	add(t.PrimaryKeyColumns())
+	for _, c := range t.Constraints {
+		if strings.EqualFold(c.Type, "UNIQUE") {
+			add(c.Columns)
+		}
+	}
+	for _, i := range t.Indexes {
+		if strings.Contains(strings.ToUpper(i.Def), "UNIQUE") {
+			add(i.Columns)
+		}
+	}
+	return uniques
+}
+
+// hasUniqueIdentifier return whether the table has the primary key or the unique constraint that Prisma Client requires.
+func hasUniqueIdentifier(t *schema.Table) bool {
+	return len(uniqueColumns(t)) > 0
+}
+
+// isUniqueIndex return whether the index is the primary key or the unique index.
+func isUniqueIndex(t *schema.Table, i *schema.Index) bool {
+	def := strings.ToUpper(i.Def)
+	if strings.Contains(def, "PRIMARY") || strings.Contains(def, "UNIQUE") {
+		return true
+	}
+	for _, c := range t.Constraints {
+		switch strings.ToUpper(c.Type) {
+		case "PRIMARY KEY", "UNIQUE":
+			if c.Name == i.Name {
+				return true
+			}
+		}
+	}
+	return strings.HasPrefix(i.Name, "sqlite_autoindex_")
+}
+
+// parseType return the lower-cased name and the arguments of the type. e.g. `varchar(255)` -> `varchar`, [`255`].
+func parseType(typ string) (string, []string) {
+	t := strings.ToLower(strings.TrimSpace(typ))
+	args := []string{}
+	if m := typeArgsRe.FindStringSubmatch(t); m != nil {
+		t = strings.TrimSpace(fmt.Sprintf("%s %s", strings.TrimSpace(m[1]), strings.TrimSpace(m[3])))
+		for _, a := range strings.Split(m[2], ",") {
+			args = append(args, strings.TrimSpace(a))
+		}
+	}
+	for _, modifier := range []string{"unsigned", "zerofill"} {
+		t = strings.TrimSpace(strings.ReplaceAll(t, modifier, ""))
+	}
+	return strings.Join(strings.Fields(t), " "), args
+}
+
+func enumValues(typ string) []string {
+	values := []string{}
+	for _, m := range enumValueRe.FindAllStringSubmatch(typ, -1) {
+		values = append(values, strings.ReplaceAll(m[1], "''", "'"))
+	}
+	return values
+}
+
+// isAutoIncrement return whether the values of the column are generated by the sequence.
+func isAutoIncrement(c *schema.Column) bool {
+	if c.Default.Valid && nextvalRe.MatchString(strings.TrimSpace(c.Default.String)) {
+		return true
+	}
+	extra := strings.ToLower(c.ExtraDef)
+	return strings.Contains(extra, "auto_increment") || strings.Contains(extra, "identity")
+}
+
+// unwrap remove the parentheses that wrap the whole value. e.g. `((0))` of SQL Server.
+func unwrap(v string) string {
+	for strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		depth := 0
+		for i, r := range v {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 && i < len(v)-1 {
+				return v
+			}
+		}
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+	return v
+}
+
+func referentialAction(action string) string {
+	switch strings.ToUpper(strings.Join(strings.Fields(action), " ")) {
+	case "CASCADE":
+		return "Cascade"
+	case "SET NULL":
+		return "SetNull"
+	case "SET DEFAULT":
+		return "SetDefault"
+	case "RESTRICT":
+		return "Restrict"
+	default:
+		return "NoAction"
+	}
+}
+
+func pascal(s string) string {
+	s = strings.ToLower(s)
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// sanitize return the valid identifier of Prisma schema. The prefix is added when the name does not start with a letter.
+func sanitize(name, prefix string) string {
+	if identRe.MatchString(name) {
+		return name
+	}
+	s := invalidCharRe.ReplaceAllString(name, "_")
+	if !identRe.MatchString(s) {
+		s = fmt.Sprintf("%s_%s", prefix, s)
+	}
+	return s
+}
+
+// rename rename the reserved names of models and enums (same as `prisma db pull`).
+func rename(name string) string {
+	if lo.Contains(reservedNames, name) {
+		return "Renamed" + name
+	}
+	return name
+}
+
+func uniqueName(names map[string]struct{}, name string) string {
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := names[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	names[unique] = struct{}{}
+	return unique
+}
+
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return fmt.Sprintf(`"%s"`, r.Replace(s))
+}
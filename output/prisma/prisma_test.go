@@ -0,0 +1,133 @@
+package prisma
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+	f := "prisma_test_schema"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, ta); err != nil {
+		t.Fatal(err)
+	}
+	f := "prisma_test_a"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestField(t *testing.T) {
+	tests := []struct {
+		provider string
+		column   *schema.Column
+		want     string
+	}{
+		{"postgresql", &schema.Column{Name: "id", Type: "integer", Default: sql.NullString{String: "nextval('a_id_seq'::regclass)", Valid: true}}, "id Int @default(autoincrement())"},
+		{"postgresql", &schema.Column{Name: "name", Type: "character varying(50)", Nullable: true, Default: sql.NullString{String: "'it''s'::character varying", Valid: true}}, `name String? @default("it's") @db.VarChar(50)`},
+		{"postgresql", &schema.Column{Name: "tags", Type: "text[]", Nullable: true}, "tags String[]"},
+		{"postgresql", &schema.Column{Name: "created", Type: "timestamp with time zone", Default: sql.NullString{String: "now()", Valid: true}}, "created DateTime @default(now())"},
+		{"postgresql", &schema.Column{Name: "uid", Type: "uuid", Default: sql.NullString{String: "gen_random_uuid()", Valid: true}}, `uid String @default(dbgenerated("gen_random_uuid()")) @db.Uuid`},
+		{"postgresql", &schema.Column{Name: "geom", Type: "geometry(Point,4326)", Nullable: true}, `geom Unsupported("geometry(Point,4326)")?`},
+		{"mysql", &schema.Column{Name: "active", Type: "tinyint(1)", Default: sql.NullString{String: "1", Valid: true}}, "active Boolean @default(true)"},
+		{"mysql", &schema.Column{Name: "title", Type: "varchar(255)", Default: sql.NullString{String: "Untitled", Valid: true}}, `title String @default("Untitled") @db.VarChar(255)`},
+		{"mysql", &schema.Column{Name: "amount", Type: "decimal(10,2) unsigned", Default: sql.NullString{String: "0.00", Valid: true}}, "amount Decimal @default(0.00) @db.Decimal(10, 2)"},
+		{"mysql", &schema.Column{Name: "status", Type: "enum('on','off')", Default: sql.NullString{String: "on", Valid: true}}, "status t_status @default(on)"},
+		{"sqlserver", &schema.Column{Name: "flag", Type: "bit", Default: sql.NullString{String: "((0))", Valid: true}}, "flag Boolean @default(false)"},
+		{"sqlserver", &schema.Column{Name: "name-with-hyphen", Type: "nvarchar(max)"}, `name_with_hyphen String @map("name-with-hyphen") @db.NVarChar(Max)`},
+		{"sqlite", &schema.Column{Name: "1st", Type: "INTEGER"}, `field_1st Int @map("1st")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.column.Name, func(t *testing.T) {
+			b := newBuilder(&schema.Driver{Name: map[string]string{"postgresql": "postgres", "mysql": "mysql", "sqlserver": "sqlserver", "sqlite": "sqlite"}[tt.provider]}, nil)
+			b.build([]*schema.Table{{Name: "t", Type: "BASE TABLE", Columns: []*schema.Column{tt.column}}}, nil)
+			f := b.models[0].fields[0]
+			got := f.name + " " + f.typ
+			for _, a := range f.attrs {
+				got += " " + a
+			}
+			if got != tt.want {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"users", "users"},
+		{"CamelizeTable", "CamelizeTable"},
+		{"hyphen-table", "hyphen_table"},
+		{"name with spaces", "name_with_spaces"},
+		{"_private", "model__private"},
+		{"1st", "model_1st"},
+	}
+	for _, tt := range tests {
+		if got := sanitize(tt.name, "model"); got != tt.want {
+			t.Errorf("got %v\nwant %v", got, tt.want)
+		}
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -0,0 +1,250 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL dialect of the DDL.
+type Dialect string
+
+const (
+	Postgres  Dialect = "postgres"
+	MySQL     Dialect = "mysql"
+	SQLite    Dialect = "sqlite"
+	SQLServer Dialect = "sqlserver"
+)
+
+// Dialects is the supported dialects.
+var Dialects = []Dialect{Postgres, MySQL, SQLite, SQLServer}
+
+// sourceDialects is the dialects of the drivers.
+var sourceDialects = map[string]Dialect{
+	"postgres":  Postgres,
+	"redshift":  Postgres,
+	"mysql":     MySQL,
+	"mariadb":   MySQL,
+	"sqlite":    SQLite,
+	"sqlserver": SQLServer,
+}
+
+// defaultSchemas is the schema used when the table name is not qualified.
+var defaultSchemas = map[Dialect]string{
+	Postgres:  "public",
+	SQLServer: "dbo",
+}
+
+// quote quote the identifier.
+func (d Dialect) quote(name string) string {
+	switch d {
+	case MySQL:
+		return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+	case SQLServer:
+		return fmt.Sprintf("[%s]", strings.ReplaceAll(name, "]", "]]"))
+	default:
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+	}
+}
+
+// literal return the string literal.
+func (d Dialect) literal(v string) string {
+	v = strings.ReplaceAll(v, "'", "''")
+	switch d {
+	case MySQL:
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(v, `\`, `\\`))
+	case SQLServer:
+		return fmt.Sprintf("N'%s'", v)
+	default:
+		return fmt.Sprintf("'%s'", v)
+	}
+}
+
+// boolean return the literal of the boolean.
+func (d Dialect) boolean(v bool) string {
+	switch {
+	case d == Postgres && v:
+		return "true"
+	case d == Postgres:
+		return "false"
+	case v:
+		return "1"
+	default:
+		return "0"
+	}
+}
+
+// hasSchemas return whether the dialect has schemas in a database.
+func (d Dialect) hasSchemas() bool {
+	return d == Postgres || d == SQLServer
+}
+
+// typeName return the name of the column type in the dialect.
+func (d Dialect) typeName(ct columnType) string {
+	args := ""
+	if len(ct.args) > 0 {
+		args = fmt.Sprintf("(%s)", strings.Join(ct.args, ","))
+	}
+	switch d {
+	case Postgres:
+		return postgresTypes(ct, args)
+	case MySQL:
+		return mysqlTypes(ct, args)
+	case SQLite:
+		return sqliteTypes(ct)
+	case SQLServer:
+		return sqlserverTypes(ct, args)
+	}
+	return ""
+}
+
+func postgresTypes(ct columnType, args string) string {
+	switch ct.kind {
+	case kindBoolean:
+		return "boolean"
+	case kindSmallInt:
+		return "smallint"
+	case kindInteger:
+		return "integer"
+	case kindBigInt:
+		return "bigint"
+	case kindDecimal:
+		return "numeric" + args
+	case kindReal:
+		return "real"
+	case kindDouble:
+		return "double precision"
+	case kindChar:
+		return "char" + args
+	case kindVarchar:
+		return "varchar" + args
+	case kindDate:
+		return "date"
+	case kindTime:
+		return "time"
+	case kindDatetime:
+		return "timestamp"
+	case kindTimestampTZ:
+		return "timestamp with time zone"
+	case kindBinary:
+		return "bytea"
+	case kindUUID:
+		return "uuid"
+	case kindJSON:
+		return "jsonb"
+	default:
+		return "text"
+	}
+}
+
+func mysqlTypes(ct columnType, args string) string {
+	switch ct.kind {
+	case kindBoolean:
+		return "tinyint(1)"
+	case kindSmallInt:
+		return "smallint"
+	case kindInteger:
+		return "int"
+	case kindBigInt:
+		return "bigint"
+	case kindDecimal:
+		return "decimal" + args
+	case kindReal:
+		return "float"
+	case kindDouble:
+		return "double"
+	case kindChar:
+		return "char" + args
+	case kindVarchar:
+		if args == "" {
+			// VARCHAR of MySQL requires the length
+			return "varchar(255)"
+		}
+		return "varchar" + args
+	case kindDate:
+		return "date"
+	case kindTime:
+		return "time"
+	case kindDatetime, kindTimestampTZ:
+		return "datetime"
+	case kindBinary:
+		return "longblob"
+	case kindUUID:
+		return "char(36)"
+	case kindJSON:
+		return "json"
+	case kindEnum:
+		values := make([]string, 0, len(ct.values))
+		for _, v := range ct.values {
+			values = append(values, MySQL.literal(v))
+		}
+		return fmt.Sprintf("enum(%s)", strings.Join(values, ","))
+	default:
+		return "text"
+	}
+}
+
+func sqliteTypes(ct columnType) string {
+	switch ct.kind {
+	case kindBoolean:
+		return "BOOLEAN"
+	case kindSmallInt, kindInteger, kindBigInt:
+		// INTEGER is required for the alias of ROWID
+		return "INTEGER"
+	case kindDecimal:
+		return "NUMERIC"
+	case kindReal, kindDouble:
+		return "REAL"
+	case kindDate:
+		return "DATE"
+	case kindDatetime, kindTimestampTZ:
+		return "DATETIME"
+	case kindBinary:
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+func sqlserverTypes(ct columnType, args string) string {
+	switch ct.kind {
+	case kindBoolean:
+		return "bit"
+	case kindSmallInt:
+		return "smallint"
+	case kindInteger:
+		return "int"
+	case kindBigInt:
+		return "bigint"
+	case kindDecimal:
+		return "decimal" + args
+	case kindReal:
+		return "real"
+	case kindDouble:
+		return "float"
+	case kindChar:
+		return "nchar" + args
+	case kindVarchar:
+		// NVARCHAR of SQL Server is up to 4000 characters
+		if n, err := strconv.Atoi(strings.Join(ct.args, "")); err != nil || n > 4000 {
+			return "nvarchar(max)"
+		}
+		return "nvarchar" + args
+	case kindDate:
+		return "date"
+	case kindTime:
+		return "time"
+	case kindDatetime:
+		return "datetime2"
+	case kindTimestampTZ:
+		return "datetimeoffset"
+	case kindBinary:
+		return "varbinary(max)"
+	case kindUUID:
+		return "uniqueidentifier"
+	case kindEnum:
+		return "nvarchar(255)"
+	default:
+		return "nvarchar(max)"
+	}
+}
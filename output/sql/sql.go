@@ -0,0 +1,784 @@
+package sql
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+var _ output.Output = &SQL{}
+
+var referentialActionRe = regexp.MustCompile(`(?i)ON\s+(DELETE|UPDATE)\s+(CASCADE|SET\s+NULL|SET\s+DEFAULT|RESTRICT|NO\s+ACTION)`)
+
+// SQL struct.
+type SQL struct {
+	config       *config.Config
+	dialect      Dialect
+	checkable    func(t *schema.Table, def string) bool
+	commentTable string
+}
+
+// New return SQL. If dialect is empty, the dialect of the driver of the schema is used.
+func New(c *config.Config, dialect string) (*SQL, error) {
+	d := Dialect(strings.ToLower(dialect))
+	if d != "" && !lo.Contains(Dialects, d) {
+		return nil, fmt.Errorf("unsupported dialect '%s'. supported dialects are %s", dialect, strings.Join(lo.Map(Dialects, func(d Dialect, _ int) string { return string(d) }), ", "))
+	}
+	return &SQL{
+		config:  c,
+		dialect: d,
+	}, nil
+}
+
+// KeepChecks keep the CHECK constraints of the other dialects that f accepts. By default, they are omitted.
+func (q *SQL) KeepChecks(f func(t *schema.Table, def string) bool) {
+	q.checkable = f
+}
+
+// StoreComments store the comments of tables and columns into the table of the name instead of the comments of the dialect.
+func (q *SQL) StoreComments(table string) {
+	q.commentTable = table
+}
+
+// OutputSchema output the DDL of the schema.
+// The tables are created in the order of the dependencies of the foreign keys.
+func (q *SQL) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	g := q.newGenerator(s)
+	g.schemas(s.Tables)
+	g.enums()
+	tables, views := separateViews(s.Tables)
+	tables = sortByDependencies(tables, s.Relations)
+	for _, t := range tables {
+		g.createTable(t)
+	}
+	for _, t := range tables {
+		g.createIndexes(t)
+	}
+	for _, t := range tables {
+		g.addForeignKeys(t)
+	}
+	g.createCommentTable()
+	for _, t := range tables {
+		g.comments(t)
+	}
+	for _, v := range views {
+		g.createView(v)
+	}
+	return g.write(wr)
+}
+
+// OutputTable output the DDL of the table.
+func (q *SQL) OutputTable(wr io.Writer, t *schema.Table) error {
+	g := q.newGenerator(nil)
+	if isView(t) {
+		g.createView(t)
+		return g.write(wr)
+	}
+	g.createTable(t)
+	g.createIndexes(t)
+	g.addForeignKeys(t)
+	g.createCommentTable()
+	g.comments(t)
+	return g.write(wr)
+}
+
+// OutputFunction output the DDL of the function (not supported).
+func (q *SQL) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// SQL format does not support individual function output
+	return nil
+}
+
+// Generator generates the statements of the DDL of the objects of a schema.
+type Generator struct {
+	source        Dialect
+	target        Dialect
+	defaultSchema string
+	enumList      []*schema.Enum
+	checkable     func(t *schema.Table, def string) bool
+	commentTable  string
+	statements    []string
+	// names is the names of the indexes and the constraints to keep them unique in the database.
+	names map[string]struct{}
+}
+
+func (q *SQL) newGenerator(s *schema.Schema) *Generator {
+	g := &Generator{
+		target:       q.dialect,
+		checkable:    q.checkable,
+		commentTable: q.commentTable,
+		names:        map[string]struct{}{},
+	}
+	if s != nil {
+		if s.Driver != nil {
+			g.source = sourceDialects[s.Driver.Name]
+			if s.Driver.Meta != nil && s.Driver.Meta.CurrentSchema != "" {
+				g.defaultSchema = s.Driver.Meta.CurrentSchema
+			}
+		}
+		g.enumList = s.Enums
+	}
+	if g.defaultSchema == "" {
+		g.defaultSchema = defaultSchemas[g.source]
+	}
+	if g.target == "" {
+		g.target = g.source
+	}
+	if g.target == "" {
+		g.target = Postgres
+	}
+	return g
+}
+
+// Generator return Generator of the statements of the objects of the schema for migrations.
+// If the dialect of the driver of the schema is unknown, the schema is assumed to be in the dialect of SQL.
+func (q *SQL) Generator(s *schema.Schema) *Generator {
+	g := q.newGenerator(s)
+	if g.source == "" {
+		g.source = g.target
+		g.defaultSchema = defaultSchemas[g.source]
+	}
+	return g
+}
+
+// TableStatements is the statements to create a table.
+type TableStatements struct {
+	// CreateTable is the CREATE TABLE statement with the primary key, the unique and check constraints ( and the foreign keys in SQLite ).
+	CreateTable []string
+	Indexes     []string
+	ForeignKeys []string
+	Comments    []string
+}
+
+// Table return the statements to create the table.
+func (g *Generator) Table(t *schema.Table) *TableStatements {
+	ts := &TableStatements{}
+	g.createTable(t)
+	ts.CreateTable = g.take()
+	g.createIndexes(t)
+	ts.Indexes = g.take()
+	g.addForeignKeys(t)
+	ts.ForeignKeys = g.take()
+	g.comments(t)
+	ts.Comments = g.take()
+	return ts
+}
+
+// Quote return the quoted identifier.
+func (g *Generator) Quote(name string) string {
+	return g.target.quote(name)
+}
+
+// TableName return the quoted name of the table ( or the other object that belongs to a schema ).
+func (g *Generator) TableName(name string) string {
+	return g.tableName(name)
+}
+
+// Literal return the string literal.
+func (g *Generator) Literal(v string) string {
+	return g.target.literal(v)
+}
+
+// ColumnDef return the definition of the column of the table and the notes of the definitions that are not converted.
+func (g *Generator) ColumnDef(t *schema.Table, c *schema.Column) (string, []string) {
+	return g.column(t, c, false)
+}
+
+// ColumnType return the type of the column.
+func (g *Generator) ColumnType(c *schema.Column) string {
+	return g.columnType(c, parseType(c.Type, g.enumList))
+}
+
+// DefaultValue return the default value of the column. It return false if the default value can not be converted.
+func (g *Generator) DefaultValue(c *schema.Column) (string, bool) {
+	return g.defaultValue(c, parseType(c.Type, g.enumList))
+}
+
+// ConstraintDef return the definition of the constraint of the table without the name.
+// It return an empty string if the constraint can not be defined in the dialect.
+func (g *Generator) ConstraintDef(t *schema.Table, c *schema.Constraint) string {
+	switch strings.ToUpper(c.Type) {
+	case "PRIMARY KEY", "UNIQUE":
+		if len(c.Columns) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%s (%s)", strings.ToUpper(c.Type), g.keyColumns(t, c.Columns))
+	case "CHECK":
+		if !strings.HasPrefix(strings.ToUpper(c.Def), "CHECK") || (!g.same() && (g.checkable == nil || !g.checkable(t, c.Def))) {
+			return ""
+		}
+		return c.Def
+	case "FOREIGN KEY":
+		if len(c.Columns) == 0 || c.ReferencedTable == nil {
+			return ""
+		}
+		columns := lo.Map(c.Columns, func(n string, _ int) string { return g.target.quote(n) })
+		parentColumns := lo.Map(c.ReferencedColumns, func(n string, _ int) string { return g.target.quote(n) })
+		return fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)%s", strings.Join(columns, ", "), g.tableName(*c.ReferencedTable), strings.Join(parentColumns, ", "), g.referentialActions(c.Def))
+	}
+	if g.same() && g.target == Postgres && strings.HasPrefix(strings.ToUpper(c.Def), "EXCLUDE") {
+		return c.Def
+	}
+	return ""
+}
+
+// CreateIndex return the CREATE INDEX statement of the index of the table.
+func (g *Generator) CreateIndex(t *schema.Table, i *schema.Index) string {
+	if g.same() && (g.target == Postgres || g.target == SQLite) && strings.HasPrefix(strings.ToUpper(i.Def), "CREATE") {
+		g.names[i.Name] = struct{}{}
+		return fmt.Sprintf("%s;", strings.TrimSuffix(i.Def, ";"))
+	}
+	unique := ""
+	if strings.Contains(strings.ToUpper(i.Def), "UNIQUE") {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);", unique, g.target.quote(g.uniqueName(t, i.Name)), g.tableName(t.Name), g.keyColumns(t, i.Columns))
+}
+
+// Comment return the statement that sets the comment of the table, or of the column of the table if c is not nil.
+// It return an empty string if the comment can not be set in the dialect.
+func (g *Generator) Comment(t *schema.Table, c *schema.Column, comment string) string {
+	switch g.target {
+	case Postgres:
+		if c != nil {
+			return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", g.tableName(t.Name), g.target.quote(c.Name), g.target.literal(comment))
+		}
+		object := "TABLE"
+		if isView(t) {
+			object = strings.ToUpper(t.Type)
+		}
+		return fmt.Sprintf("COMMENT ON %s %s IS %s;", object, g.tableName(t.Name), g.target.literal(comment))
+	case MySQL:
+		if isView(t) {
+			return ""
+		}
+		if c == nil {
+			return fmt.Sprintf("ALTER TABLE %s COMMENT = %s;", g.tableName(t.Name), g.target.literal(comment))
+		}
+		// MySQL sets the comment of the column by redefining the whole column
+		cc := *c
+		cc.Comment = comment
+		def, _ := g.column(t, &cc, false)
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", g.tableName(t.Name), def)
+	case SQLServer:
+		sn, tn := g.splitName(t.Name)
+		if sn == "" {
+			sn = defaultSchemas[SQLServer]
+		}
+		object := "TABLE"
+		if isView(t) {
+			object = "VIEW"
+		}
+		stmt := fmt.Sprintf("EXEC sp_addextendedproperty @name = N'MS_Description', @value = %s, @level0type = N'SCHEMA', @level0name = %s, @level1type = N'%s', @level1name = %s", g.target.literal(comment), g.target.literal(sn), object, g.target.literal(tn))
+		if c != nil {
+			stmt += fmt.Sprintf(", @level2type = N'COLUMN', @level2name = %s", g.target.literal(c.Name))
+		}
+		return stmt + ";"
+	}
+	return ""
+}
+
+// take return the statements and clear them.
+func (g *Generator) take() []string {
+	stmts := g.statements
+	g.statements = nil
+	return stmts
+}
+
+// same return whether the dialect of the source is the same as the target, then the definitions are output as they are.
+func (g *Generator) same() bool {
+	return g.source == g.target
+}
+
+func (g *Generator) add(format string, a ...any) {
+	g.statements = append(g.statements, fmt.Sprintf(format, a...))
+}
+
+func (g *Generator) write(wr io.Writer) error {
+	for i, s := range g.statements {
+		if i > 0 {
+			if _, err := io.WriteString(wr, "\n"); err != nil {
+				return errors.WithStack(err)
+			}
+		}
+		if _, err := io.WriteString(wr, s+"\n"); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// splitName split the table name into the schema name and the table name.
+func (g *Generator) splitName(name string) (string, string) {
+	if !g.source.hasSchemas() || !strings.Contains(name, ".") {
+		return "", name
+	}
+	s := strings.SplitN(name, ".", 2)
+	if s[0] == g.defaultSchema {
+		return "", s[1]
+	}
+	return s[0], s[1]
+}
+
+// tableName return the quoted table name.
+// The schema of the source is kept in the dialects that have schemas, otherwise it is a part of the table name.
+func (g *Generator) tableName(name string) string {
+	sn, tn := g.splitName(name)
+	switch {
+	case sn == "":
+		return g.target.quote(tn)
+	case g.target.hasSchemas():
+		return fmt.Sprintf("%s.%s", g.target.quote(sn), g.target.quote(tn))
+	default:
+		return g.target.quote(fmt.Sprintf("%s.%s", sn, tn))
+	}
+}
+
+// uniqueName return the name that is unique in the database.
+func (g *Generator) uniqueName(t *schema.Table, name string) string {
+	_, tn := g.splitName(t.Name)
+	unique := name
+	for i := 1; ; i++ {
+		if _, ok := g.names[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%s", tn, name)
+		if i > 1 {
+			unique = fmt.Sprintf("%s_%s_%d", tn, name, i)
+		}
+	}
+	g.names[unique] = struct{}{}
+	return unique
+}
+
+func (g *Generator) schemas(tables []*schema.Table) {
+	if !g.target.hasSchemas() {
+		return
+	}
+	schemas := []string{}
+	for _, t := range tables {
+		sn, _ := g.splitName(t.Name)
+		if sn == "" || sn == defaultSchemas[g.target] || lo.Contains(schemas, sn) {
+			continue
+		}
+		schemas = append(schemas, sn)
+	}
+	for _, sn := range schemas {
+		switch g.target {
+		case Postgres:
+			g.add("CREATE SCHEMA IF NOT EXISTS %s;", g.target.quote(sn))
+		case SQLServer:
+			g.add("IF SCHEMA_ID(%s) IS NULL EXEC(%s);", g.target.literal(sn), g.target.literal(fmt.Sprintf("CREATE SCHEMA %s", g.target.quote(sn))))
+		}
+	}
+}
+
+// enums create the enum types of PostgreSQL. In the other dialects, the values of enums are constrained per column.
+func (g *Generator) enums() {
+	if g.target != Postgres || !g.same() {
+		return
+	}
+	for _, e := range g.enumList {
+		values := lo.Map(e.Values, func(v string, _ int) string { return g.target.literal(v) })
+		g.add("CREATE TYPE %s AS ENUM (%s);", g.tableName(e.Name), strings.Join(values, ", "))
+	}
+}
+
+func (g *Generator) createTable(t *schema.Table) {
+	notes := []string{}
+	lines := []string{}
+	pks := t.PrimaryKeyColumns()
+	// the single INTEGER PRIMARY KEY column of SQLite is auto-incremented
+	inlinePK := ""
+	if g.target == SQLite && len(pks) == 1 {
+		if c, err := t.FindColumnByName(pks[0]); err == nil && isAutoIncrement(c) && g.sqliteInteger(c) {
+			inlinePK = c.Name
+		}
+	}
+	for _, c := range t.Columns {
+		line, n := g.column(t, c, c.Name == inlinePK)
+		lines = append(lines, line)
+		notes = append(notes, n...)
+	}
+	if len(pks) > 0 && inlinePK == "" {
+		lines = append(lines, fmt.Sprintf("%sPRIMARY KEY (%s)", g.constraintName(t, "PRIMARY KEY"), g.keyColumns(t, pks)))
+	}
+	for _, c := range t.Constraints {
+		switch strings.ToUpper(c.Type) {
+		case "UNIQUE":
+			if def := g.ConstraintDef(t, c); def != "" {
+				lines = append(lines, fmt.Sprintf("%s%s", g.namedConstraint(t, c.Name), def))
+			}
+		case "CHECK":
+			def := g.ConstraintDef(t, c)
+			if def == "" {
+				if strings.HasPrefix(strings.ToUpper(c.Def), "CHECK") {
+					notes = append(notes, fmt.Sprintf("%s is omitted: %s", c.Name, c.Def))
+				}
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s%s", g.namedConstraint(t, c.Name), def))
+		}
+	}
+	if g.target == SQLite {
+		// SQLite can not add foreign keys to the existing tables
+		for _, r := range parentRelations(t) {
+			lines = append(lines, g.foreignKey(t, r))
+		}
+	}
+	b := &strings.Builder{}
+	for _, n := range notes {
+		fmt.Fprintf(b, "-- %s\n", oneLine(n))
+	}
+	if g.target == SQLite && t.Comment != "" {
+		for _, l := range strings.Split(t.Comment, "\n") {
+			fmt.Fprintf(b, "-- %s\n", strings.TrimRight(l, "\r"))
+		}
+	}
+	fmt.Fprintf(b, "CREATE TABLE %s (\n  %s\n)", g.tableName(t.Name), strings.Join(lines, ",\n  "))
+	if g.target == MySQL && t.Comment != "" {
+		fmt.Fprintf(b, " COMMENT=%s", g.target.literal(t.Comment))
+	}
+	b.WriteString(";")
+	g.statements = append(g.statements, b.String())
+}
+
+// column return the definition of the column and the notes of the definitions that are not converted.
+func (g *Generator) column(t *schema.Table, c *schema.Column, inlinePK bool) (string, []string) {
+	notes := []string{}
+	ct := parseType(c.Type, g.enumList)
+	def := []string{g.target.quote(c.Name)}
+	if typ := g.columnType(c, ct); typ != "" {
+		def = append(def, typ)
+	}
+	autoIncrement := isAutoIncrement(c)
+	switch {
+	case inlinePK:
+		def = append(def, "PRIMARY KEY AUTOINCREMENT")
+	case autoIncrement && g.target == Postgres:
+		def = append(def, "GENERATED BY DEFAULT AS IDENTITY")
+	case autoIncrement && g.target == MySQL:
+		def = append(def, "AUTO_INCREMENT")
+	case autoIncrement && g.target == SQLServer:
+		def = append(def, "IDENTITY(1,1)")
+	}
+	if isGenerated(c) {
+		if g.same() {
+			def = append(def, c.ExtraDef)
+		} else {
+			notes = append(notes, fmt.Sprintf("%s.%s is not generated: %s", t.Name, c.Name, c.ExtraDef))
+		}
+	}
+	if !c.Nullable {
+		def = append(def, "NOT NULL")
+	}
+	if c.Default.Valid && !autoIncrement && !isGenerated(c) {
+		if v, ok := g.defaultValue(c, ct); ok {
+			def = append(def, fmt.Sprintf("DEFAULT %s", v))
+		} else {
+			notes = append(notes, fmt.Sprintf("the default of %s.%s is omitted: %s", t.Name, c.Name, c.Default.String))
+		}
+	}
+	if g.same() && g.target == MySQL {
+		if u := onUpdateRe.FindString(c.ExtraDef); u != "" {
+			def = append(def, strings.ToUpper(u))
+		}
+	}
+	if ct.kind == kindEnum && g.target != MySQL && !(g.same() && g.target == Postgres) {
+		values := lo.Map(ct.values, func(v string, _ int) string { return g.target.literal(v) })
+		def = append(def, fmt.Sprintf("CHECK (%s IN (%s))", g.target.quote(c.Name), strings.Join(values, ", ")))
+	}
+	if g.target == MySQL && c.Comment != "" {
+		def = append(def, fmt.Sprintf("COMMENT %s", g.target.literal(c.Comment)))
+	}
+	return strings.Join(def, " "), notes
+}
+
+// columnType return the column type in the target dialect.
+// The type is output as it is if the dialect of the source is the same.
+func (g *Generator) columnType(c *schema.Column, ct columnType) string {
+	if g.same() {
+		return c.Type
+	}
+	return g.target.typeName(ct)
+}
+
+func (g *Generator) sqliteInteger(c *schema.Column) bool {
+	return strings.EqualFold(g.columnType(c, parseType(c.Type, g.enumList)), "INTEGER")
+}
+
+// defaultValue return the default value in the target dialect.
+// The default values that can not be converted are omitted.
+func (g *Generator) defaultValue(c *schema.Column, ct columnType) (string, bool) {
+	v := strings.TrimSpace(c.Default.String)
+	if g.source == MySQL {
+		v = mysqlDefault(c, v, ct)
+	}
+	if g.same() {
+		return v, true
+	}
+	v = unwrap(v)
+	if m := castRe.FindStringSubmatch(v); m != nil {
+		v = unwrap(m[1])
+	}
+	switch {
+	case strings.EqualFold(v, "NULL"):
+		return "NULL", true
+	case ct.kind == kindBoolean && (strings.EqualFold(v, "true") || v == "1" || strings.EqualFold(v, "b'1'")):
+		return g.target.boolean(true), true
+	case ct.kind == kindBoolean && (strings.EqualFold(v, "false") || v == "0" || strings.EqualFold(v, "b'0'")):
+		return g.target.boolean(false), true
+	case numberRe.MatchString(v):
+		return v, true
+	case stringRe.MatchString(v):
+		m := stringRe.FindStringSubmatch(v)
+		return g.target.literal(strings.ReplaceAll(m[1], "''", "'")), true
+	case currentTimeRe.MatchString(v):
+		return "CURRENT_TIMESTAMP", true
+	case currentDateRe.MatchString(v):
+		return "CURRENT_DATE", true
+	}
+	return "", false
+}
+
+// mysqlDefault return the default value of MySQL as the SQL expression, because the values of strings are not quoted in information_schema.
+func mysqlDefault(c *schema.Column, v string, ct columnType) string {
+	switch {
+	case strings.Contains(strings.ToUpper(c.ExtraDef), "DEFAULT_GENERATED"):
+		if currentTimeRe.MatchString(v) || currentDateRe.MatchString(v) {
+			return v
+		}
+		return fmt.Sprintf("(%s)", v)
+	case strings.EqualFold(v, "NULL"), stringRe.MatchString(v):
+		return v
+	case numberRe.MatchString(v) && ct.kind != kindChar && ct.kind != kindVarchar && ct.kind != kindText && ct.kind != kindEnum:
+		return v
+	}
+	return MySQL.literal(v)
+}
+
+// constraintName return the `CONSTRAINT name` clause of the constraint of the type.
+func (g *Generator) constraintName(t *schema.Table, typ string) string {
+	for _, c := range t.Constraints {
+		if strings.EqualFold(c.Type, typ) {
+			return g.namedConstraint(t, c.Name)
+		}
+	}
+	return ""
+}
+
+// namedConstraint return the `CONSTRAINT name` clause. The names generated by the databases are omitted.
+func (g *Generator) namedConstraint(t *schema.Table, name string) string {
+	if name == "" || name == "PRIMARY" || strings.HasPrefix(name, "sqlite_autoindex_") {
+		return ""
+	}
+	return fmt.Sprintf("CONSTRAINT %s ", g.target.quote(g.uniqueName(t, name)))
+}
+
+// keyColumns return the columns of the key. MySQL requires the length of the key of TEXT and BLOB.
+func (g *Generator) keyColumns(t *schema.Table, columns []string) string {
+	keys := []string{}
+	for _, name := range columns {
+		key := g.target.quote(name)
+		if g.target == MySQL {
+			if c, err := t.FindColumnByName(name); err == nil {
+				typ := strings.ToLower(g.columnType(c, parseType(c.Type, g.enumList)))
+				if strings.HasSuffix(typ, "text") || strings.HasSuffix(typ, "blob") {
+					key = fmt.Sprintf("%s(255)", key)
+				}
+			}
+		}
+		keys = append(keys, key)
+	}
+	return strings.Join(keys, ", ")
+}
+
+func (g *Generator) createIndexes(t *schema.Table) {
+	for _, i := range t.Indexes {
+		if len(i.Columns) == 0 || g.isConstraintIndex(t, i) {
+			continue
+		}
+		g.statements = append(g.statements, g.CreateIndex(t, i))
+	}
+}
+
+// isConstraintIndex return whether the index is created by the primary key or the unique constraint.
+func (g *Generator) isConstraintIndex(t *schema.Table, i *schema.Index) bool {
+	if strings.Contains(strings.ToUpper(i.Def), "PRIMARY") {
+		return true
+	}
+	for _, c := range t.Constraints {
+		switch strings.ToUpper(c.Type) {
+		case "PRIMARY KEY", "UNIQUE":
+			if c.Name == i.Name {
+				return true
+			}
+		}
+	}
+	// the indexes of SQLite for the constraints
+	return strings.HasPrefix(i.Name, "sqlite_autoindex_")
+}
+
+func (g *Generator) addForeignKeys(t *schema.Table) {
+	if g.target == SQLite {
+		return
+	}
+	for _, r := range parentRelations(t) {
+		g.add("ALTER TABLE %s ADD %s;", g.tableName(t.Name), g.foreignKey(t, r))
+	}
+}
+
+// foreignKey return the foreign key constraint of the relation.
+func (g *Generator) foreignKey(t *schema.Table, r *schema.Relation) string {
+	b := &strings.Builder{}
+	for _, c := range t.Constraints {
+		if strings.EqualFold(c.Type, "FOREIGN KEY") && c.Def == r.Def {
+			b.WriteString(g.namedConstraint(t, c.Name))
+			break
+		}
+	}
+	columns := lo.Map(r.Columns, func(c *schema.Column, _ int) string { return g.target.quote(c.Name) })
+	parentColumns := lo.Map(r.ParentColumns, func(c *schema.Column, _ int) string { return g.target.quote(c.Name) })
+	fmt.Fprintf(b, "FOREIGN KEY (%s) REFERENCES %s (%s)%s", strings.Join(columns, ", "), g.tableName(r.ParentTable.Name), strings.Join(parentColumns, ", "), g.referentialActions(r.Def))
+	return b.String()
+}
+
+// referentialActions return the `ON DELETE` and `ON UPDATE` clauses of the definition of the foreign key.
+func (g *Generator) referentialActions(def string) string {
+	b := &strings.Builder{}
+	for _, m := range referentialActionRe.FindAllStringSubmatch(def, -1) {
+		action := strings.ToUpper(strings.Join(strings.Fields(m[2]), " "))
+		if action == "RESTRICT" && g.target == SQLServer {
+			action = "NO ACTION"
+		}
+		fmt.Fprintf(b, " ON %s %s", strings.ToUpper(m[1]), action)
+	}
+	return b.String()
+}
+
+// createCommentTable create the table that stores the comments.
+func (g *Generator) createCommentTable() {
+	if g.commentTable == "" {
+		return
+	}
+	text := g.target.typeName(columnType{kind: kindText})
+	g.add("CREATE TABLE %s (\n  %s %s NOT NULL,\n  %s %s,\n  %s %s NOT NULL\n);", g.target.quote(g.commentTable),
+		g.target.quote("table_name"), text, g.target.quote("column_name"), text, g.target.quote("comment"), text)
+}
+
+func (g *Generator) comments(t *schema.Table) {
+	if g.commentTable != "" {
+		g.storeComments(t)
+		return
+	}
+	if g.target != Postgres && g.target != SQLServer {
+		return
+	}
+	if t.Comment != "" {
+		g.add("%s", g.Comment(t, nil, t.Comment))
+	}
+	for _, c := range t.Columns {
+		if c.Comment == "" {
+			continue
+		}
+		g.add("%s", g.Comment(t, c, c.Comment))
+	}
+}
+
+// storeComments insert the comments of the table and the columns into the comment table.
+// The comment of the table is stored with NULL column_name.
+func (g *Generator) storeComments(t *schema.Table) {
+	sn, tn := g.splitName(t.Name)
+	if sn != "" {
+		tn = fmt.Sprintf("%s.%s", sn, tn)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s, %s, %s) VALUES", g.target.quote(g.commentTable), g.target.quote("table_name"), g.target.quote("column_name"), g.target.quote("comment"))
+	if t.Comment != "" {
+		g.add("%s (%s, NULL, %s);", insert, g.target.literal(tn), g.target.literal(t.Comment))
+	}
+	for _, c := range t.Columns {
+		if c.Comment == "" {
+			continue
+		}
+		g.add("%s (%s, %s, %s);", insert, g.target.literal(tn), g.target.literal(c.Name), g.target.literal(c.Comment))
+	}
+}
+
+func (g *Generator) createView(t *schema.Table) {
+	if !g.same() || t.Def == "" {
+		g.add("-- %s %s is not converted", strings.ToLower(t.Type), t.Name)
+		return
+	}
+	def := strings.TrimSuffix(strings.TrimSpace(t.Def), ";")
+	if g.target == SQLServer {
+		// CREATE VIEW must be the first statement in a batch
+		g.add("GO\n%s;\nGO", def)
+		return
+	}
+	g.add("%s;", def)
+}
+
+// parentRelations return the relations of the foreign keys of the table.
+func parentRelations(t *schema.Table) []*schema.Relation {
+	relations := []*schema.Relation{}
+	for _, c := range t.Columns {
+		for _, r := range c.ParentRelations {
+			if r.Virtual || r.Table != t || lo.Contains(relations, r) {
+				continue
+			}
+			relations = append(relations, r)
+		}
+	}
+	return relations
+}
+
+func isView(t *schema.Table) bool {
+	return strings.Contains(strings.ToUpper(t.Type), "VIEW")
+}
+
+func separateViews(tables []*schema.Table) ([]*schema.Table, []*schema.Table) {
+	return lo.Filter(tables, func(t *schema.Table, _ int) bool { return !isView(t) }),
+		lo.Filter(tables, func(t *schema.Table, _ int) bool { return isView(t) })
+}
+
+// sortByDependencies sort the tables so that the parent tables of foreign keys are created first.
+// The order of the tables in the cycles of the dependencies is kept.
+func sortByDependencies(tables []*schema.Table, relations []*schema.Relation) []*schema.Table {
+	parents := map[*schema.Table][]*schema.Table{}
+	for _, r := range relations {
+		if r.Virtual || r.Table == r.ParentTable {
+			continue
+		}
+		parents[r.Table] = append(parents[r.Table], r.ParentTable)
+	}
+	sorted := []*schema.Table{}
+	visited := map[*schema.Table]bool{}
+	var visit func(t *schema.Table)
+	visit = func(t *schema.Table) {
+		if _, ok := visited[t]; ok {
+			return
+		}
+		visited[t] = false
+		for _, p := range parents[t] {
+			if lo.Contains(tables, p) {
+				visit(p)
+			}
+		}
+		visited[t] = true
+		sorted = append(sorted, t)
+	}
+	for _, t := range tables {
+		visit(t)
+	}
+	return sorted
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,189 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+	"github.com/tenntenn/golden"
+)
+
+func TestOutputSchema(t *testing.T) {
+	for _, d := range Dialects {
+		t.Run(string(d), func(t *testing.T) {
+			s := testutil.NewSchema(t)
+			c, err := config.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.ModifySchema(s); err != nil {
+				t.Fatal(err)
+			}
+			o, err := New(c, string(d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &bytes.Buffer{}
+			if err := o.OutputSchema(got, s); err != nil {
+				t.Fatal(err)
+			}
+			f := fmt.Sprintf("sql_test_schema.%s", d)
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				golden.Update(t, testdataDir(), f, got.String())
+				return
+			}
+			if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	tb, err := s.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := New(c, "postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, tb); err != nil {
+		t.Fatal(err)
+	}
+	f := "sql_test_b"
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		golden.Update(t, testdataDir(), f, got.String())
+		return
+	}
+	if diff := golden.Diff(t, testdataDir(), f, got.String()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		dialect string
+		wantErr bool
+	}{
+		{"", false},
+		{"postgres", false},
+		{"MySQL", false},
+		{"sqlite", false},
+		{"sqlserver", false},
+		{"oracle", true},
+	}
+	for _, tt := range tests {
+		c, err := config.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = New(c, tt.dialect)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, wantErr %v", tt.dialect, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want columnType
+	}{
+		{"integer", columnType{kind: kindInteger, args: []string{}}},
+		{"int(11)", columnType{kind: kindInteger, args: []string{}}},
+		{"int unsigned", columnType{kind: kindInteger, args: []string{}}},
+		{"bigint(20) unsigned zerofill", columnType{kind: kindBigInt, args: []string{}}},
+		{"tinyint(1)", columnType{kind: kindBoolean, args: []string{}}},
+		{"tinyint(4)", columnType{kind: kindSmallInt, args: []string{}}},
+		{"bit(8)", columnType{kind: kindBinary, args: []string{}}},
+		{"character varying(255)", columnType{kind: kindVarchar, args: []string{"255"}}},
+		{"nvarchar(max)", columnType{kind: kindText, args: []string{}}},
+		{"numeric(10, 2)", columnType{kind: kindDecimal, args: []string{"10", "2"}}},
+		{"timestamp(6) with time zone", columnType{kind: kindTimestampTZ, args: []string{}}},
+		{"datetime2(7)", columnType{kind: kindDatetime, args: []string{}}},
+		{"text[]", columnType{kind: kindJSON}},
+		{"enum('a','It''s')", columnType{kind: kindEnum, values: []string{"a", "It's"}}},
+		{"post_types", columnType{kind: kindEnum, values: []string{"draft", "public"}}},
+		{"geometry", columnType{kind: kindUnknown}},
+	}
+	enums := []*schema.Enum{{Name: "public.post_types", Values: []string{"draft", "public"}}}
+	for _, tt := range tests {
+		got := parseType(tt.typ, enums)
+		if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(columnType{})); diff != "" {
+			t.Errorf("%s: %s", tt.typ, diff)
+		}
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want map[Dialect]string
+	}{
+		{"int unsigned", map[Dialect]string{Postgres: "integer", MySQL: "int", SQLite: "INTEGER", SQLServer: "int"}},
+		{"varchar", map[Dialect]string{Postgres: "varchar", MySQL: "varchar(255)", SQLite: "TEXT", SQLServer: "nvarchar(max)"}},
+		{"varchar(8000)", map[Dialect]string{Postgres: "varchar(8000)", MySQL: "varchar(8000)", SQLite: "TEXT", SQLServer: "nvarchar(max)"}},
+		{"tinyint(1)", map[Dialect]string{Postgres: "boolean", MySQL: "tinyint(1)", SQLite: "BOOLEAN", SQLServer: "bit"}},
+		{"timestamptz", map[Dialect]string{Postgres: "timestamp with time zone", MySQL: "datetime", SQLite: "DATETIME", SQLServer: "datetimeoffset"}},
+		{"uuid", map[Dialect]string{Postgres: "uuid", MySQL: "char(36)", SQLite: "TEXT", SQLServer: "uniqueidentifier"}},
+		{"jsonb", map[Dialect]string{Postgres: "jsonb", MySQL: "json", SQLite: "TEXT", SQLServer: "nvarchar(max)"}},
+		{"enum('a','b')", map[Dialect]string{Postgres: "text", MySQL: "enum('a','b')", SQLite: "TEXT", SQLServer: "nvarchar(255)"}},
+	}
+	for _, tt := range tests {
+		ct := parseType(tt.typ, nil)
+		for _, d := range Dialects {
+			if got := d.typeName(ct); got != tt.want[d] {
+				t.Errorf("%s (%s): got %v want %v", tt.typ, d, got, tt.want[d])
+			}
+		}
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	a := &schema.Table{Name: "a"}
+	b := &schema.Table{Name: "b"}
+	c := &schema.Table{Name: "c"}
+	d := &schema.Table{Name: "d"}
+	relations := []*schema.Relation{
+		{Table: a, ParentTable: b},
+		{Table: b, ParentTable: c},
+		{Table: c, ParentTable: c},
+		{Table: d, ParentTable: a, Virtual: true},
+	}
+	got := []string{}
+	for _, t := range sortByDependencies([]*schema.Table{d, a, b, c}, relations) {
+		got = append(got, t.Name)
+	}
+	want := []string{"d", "c", "b", "a"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -0,0 +1,237 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/k1LoW/tbls/schema"
+)
+
+// kind is the kind of the column type independent of dialects.
+type kind int
+
+const (
+	kindUnknown kind = iota
+	kindBoolean
+	kindSmallInt
+	kindInteger
+	kindBigInt
+	kindDecimal
+	kindReal
+	kindDouble
+	kindChar
+	kindVarchar
+	kindText
+	kindDate
+	kindTime
+	kindDatetime
+	kindTimestampTZ
+	kindBinary
+	kindUUID
+	kindJSON
+	kindEnum
+)
+
+var (
+	typeArgsRe     = regexp.MustCompile(`^([^(]+)\(([^)]*)\)(.*)$`)
+	enumRe         = regexp.MustCompile(`^(?:enum|set)\s*\((.*)\)$`)
+	enumValueRe    = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	numberRe       = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+	stringRe       = regexp.MustCompile(`^[Nn]?'((?:[^']|'')*)'$`)
+	castRe         = regexp.MustCompile(`^(.+?)(::[a-zA-Z_][a-zA-Z0-9_ ."]*(\([0-9, ]*\))?(\[\])?)+$`)
+	currentTimeRe  = regexp.MustCompile(`(?i)^(current_timestamp|now|getdate|getutcdate|sysdatetime|sysutcdatetime|localtimestamp|transaction_timestamp|statement_timestamp|clock_timestamp)\s*(\([0-9]*\))?$`)
+	currentDateRe  = regexp.MustCompile(`(?i)^(current_date|curdate)\s*(\(\))?$`)
+	nextvalRe      = regexp.MustCompile(`(?i)^nextval\(`)
+	onUpdateRe     = regexp.MustCompile(`(?i)on update [a-z_]+(\([0-9]*\))?`)
+	autoIncrements = []string{"auto_increment", "autoincrement", "identity"}
+	typeModifiers  = []string{"unsigned", "zerofill", "identity"}
+)
+
+var kinds = map[string]kind{
+	"bool":                        kindBoolean,
+	"boolean":                     kindBoolean,
+	"bit":                         kindBoolean,
+	"tinyint":                     kindSmallInt,
+	"smallint":                    kindSmallInt,
+	"int2":                        kindSmallInt,
+	"smallserial":                 kindSmallInt,
+	"serial2":                     kindSmallInt,
+	"mediumint":                   kindInteger,
+	"int":                         kindInteger,
+	"integer":                     kindInteger,
+	"int4":                        kindInteger,
+	"serial":                      kindInteger,
+	"serial4":                     kindInteger,
+	"bigint":                      kindBigInt,
+	"int8":                        kindBigInt,
+	"int64":                       kindBigInt,
+	"bigserial":                   kindBigInt,
+	"serial8":                     kindBigInt,
+	"decimal":                     kindDecimal,
+	"numeric":                     kindDecimal,
+	"number":                      kindDecimal,
+	"money":                       kindDecimal,
+	"smallmoney":                  kindDecimal,
+	"real":                        kindReal,
+	"float4":                      kindReal,
+	"float":                       kindDouble,
+	"float8":                      kindDouble,
+	"float64":                     kindDouble,
+	"double":                      kindDouble,
+	"double precision":            kindDouble,
+	"char":                        kindChar,
+	"character":                   kindChar,
+	"nchar":                       kindChar,
+	"bpchar":                      kindChar,
+	"varchar":                     kindVarchar,
+	"character varying":           kindVarchar,
+	"nvarchar":                    kindVarchar,
+	"varchar2":                    kindVarchar,
+	"nvarchar2":                   kindVarchar,
+	"text":                        kindText,
+	"tinytext":                    kindText,
+	"mediumtext":                  kindText,
+	"longtext":                    kindText,
+	"ntext":                       kindText,
+	"clob":                        kindText,
+	"string":                      kindText,
+	"citext":                      kindText,
+	"date":                        kindDate,
+	"time":                        kindTime,
+	"time without time zone":      kindTime,
+	"time with time zone":         kindTime,
+	"timetz":                      kindTime,
+	"timestamp":                   kindDatetime,
+	"timestamp without time zone": kindDatetime,
+	"datetime":                    kindDatetime,
+	"datetime2":                   kindDatetime,
+	"smalldatetime":               kindDatetime,
+	"timestamp with time zone":    kindTimestampTZ,
+	"timestamptz":                 kindTimestampTZ,
+	"datetimeoffset":              kindTimestampTZ,
+	"bytea":                       kindBinary,
+	"blob":                        kindBinary,
+	"tinyblob":                    kindBinary,
+	"mediumblob":                  kindBinary,
+	"longblob":                    kindBinary,
+	"binary":                      kindBinary,
+	"varbinary":                   kindBinary,
+	"image":                       kindBinary,
+	"bytes":                       kindBinary,
+	"uuid":                        kindUUID,
+	"uniqueidentifier":            kindUUID,
+	"json":                        kindJSON,
+	"jsonb":                       kindJSON,
+}
+
+// columnType is the column type parsed from the type of the source dialect.
+type columnType struct {
+	kind   kind
+	args   []string
+	values []string
+}
+
+// parseType parse the column type. The types of enums are resolved with the enums of the schema.
+func parseType(typ string, enums []*schema.Enum) columnType {
+	t := strings.ToLower(strings.TrimSpace(typ))
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "array") {
+		return columnType{kind: kindJSON}
+	}
+	if enumRe.MatchString(t) {
+		// use the values of the original type to keep the case
+		return columnType{kind: kindEnum, values: enumValues(typ)}
+	}
+	for _, e := range enums {
+		if strings.EqualFold(e.Name, typ) || strings.HasSuffix(strings.ToLower(e.Name), "."+t) {
+			return columnType{kind: kindEnum, values: e.Values}
+		}
+	}
+	name := t
+	args := []string{}
+	if m := typeArgsRe.FindStringSubmatch(t); m != nil {
+		// e.g. `varchar(255)`, `timestamp(6) with time zone`
+		name = strings.TrimSpace(fmt.Sprintf("%s %s", strings.TrimSpace(m[1]), strings.TrimSpace(m[3])))
+		for _, a := range strings.Split(m[2], ",") {
+			args = append(args, strings.TrimSpace(a))
+		}
+	}
+	for _, modifier := range typeModifiers {
+		name = strings.TrimSpace(strings.ReplaceAll(name, modifier, ""))
+	}
+	k, ok := kinds[name]
+	if !ok {
+		return columnType{kind: kindUnknown}
+	}
+	switch {
+	case name == "tinyint" && len(args) == 1 && args[0] == "1":
+		// tinyint(1) is the boolean of MySQL
+		k = kindBoolean
+	case name == "bit" && len(args) == 1 && args[0] != "1":
+		k = kindBinary
+	case (k == kindVarchar || k == kindBinary) && len(args) == 1 && strings.EqualFold(args[0], "max"):
+		if k == kindVarchar {
+			k = kindText
+		}
+		args = []string{}
+	}
+	switch k {
+	case kindChar, kindVarchar, kindDecimal:
+	default:
+		// the arguments of the other types (e.g. `int(11)`, `datetime2(7)`) are not portable
+		args = []string{}
+	}
+	return columnType{kind: k, args: args}
+}
+
+func enumValues(typ string) []string {
+	values := []string{}
+	for _, m := range enumValueRe.FindAllStringSubmatch(typ, -1) {
+		values = append(values, strings.ReplaceAll(m[1], "''", "'"))
+	}
+	return values
+}
+
+// isAutoIncrement return whether the values of the column are generated by the sequence.
+func isAutoIncrement(c *schema.Column) bool {
+	if c.Default.Valid && nextvalRe.MatchString(strings.TrimSpace(c.Default.String)) {
+		return true
+	}
+	extra := strings.ToLower(c.ExtraDef)
+	for _, a := range autoIncrements {
+		if strings.Contains(extra, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGenerated return whether the column is the generated column.
+func isGenerated(c *schema.Column) bool {
+	return strings.HasPrefix(strings.ToUpper(c.ExtraDef), "GENERATED ALWAYS AS")
+}
+
+// unwrap remove the parentheses that wrap the whole value. e.g. `((0))` of SQL Server.
+func unwrap(v string) string {
+	for strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		depth := 0
+		wrapped := true
+		for i, r := range v {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 && i < len(v)-1 {
+				wrapped = false
+				break
+			}
+		}
+		if !wrapped {
+			return v
+		}
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+	return v
+}
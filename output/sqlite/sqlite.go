@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/output"
+	ddl "github.com/k1LoW/tbls/output/sql"
+	"github.com/k1LoW/tbls/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/samber/lo"
+)
+
+// CommentTable is the table that stores the comments of tables and columns.
+const CommentTable = "_tbls_comments"
+
+// probeTable is the temporary table to check whether SQLite can create the CHECK constraint.
+const probeTable = "_tbls_probe"
+
+var _ output.Output = &SQLite{}
+
+// SQLite struct.
+type SQLite struct {
+	config *config.Config
+}
+
+// New return SQLite.
+func New(c *config.Config) *SQLite {
+	return &SQLite{
+		config: c,
+	}
+}
+
+// OutputSchema output the SQLite database file that has the tables of the schema.
+func (l *SQLite) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	return l.output(wr, func(o *ddl.SQL, b io.Writer) error {
+		return o.OutputSchema(b, s)
+	})
+}
+
+// OutputTable output the SQLite database file that has the table.
+func (l *SQLite) OutputTable(wr io.Writer, t *schema.Table) error {
+	return l.output(wr, func(o *ddl.SQL, b io.Writer) error {
+		return o.OutputTable(b, t)
+	})
+}
+
+// OutputFunction output the SQLite database file for function (not supported).
+func (l *SQLite) OutputFunction(wr io.Writer, f *schema.Function) error {
+	// SQLite format does not support individual function output
+	return nil
+}
+
+func (l *SQLite) output(wr io.Writer, gen func(o *ddl.SQL, b io.Writer) error) (e error) {
+	dir, err := os.MkdirTemp("", "tbls")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil && e == nil {
+			e = errors.WithStack(err)
+		}
+	}()
+	path := filepath.Join(dir, "tbls.sqlite3")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// the temporary tables are per connection
+	db.SetMaxOpenConns(1)
+	o, err := ddl.New(l.config, string(ddl.SQLite))
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	o.KeepChecks(checkable(db))
+	o.StoreComments(CommentTable)
+	b := &bytes.Buffer{}
+	if err := gen(o, b); err != nil {
+		_ = db.Close()
+		return err
+	}
+	if _, err := db.Exec(b.String()); err != nil {
+		_ = db.Close()
+		return errors.WithStack(err)
+	}
+	if err := db.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && e == nil {
+			e = errors.WithStack(err)
+		}
+	}()
+	if _, err := io.Copy(wr, f); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// checkable return the function that checks whether SQLite can create the CHECK constraint on the columns of the table.
+func checkable(db *sql.DB) func(t *schema.Table, def string) bool {
+	return func(t *schema.Table, def string) bool {
+		columns := lo.Map(t.Columns, func(c *schema.Column, _ int) string { return quote(c.Name) })
+		if _, err := db.Exec(fmt.Sprintf("CREATE TEMP TABLE %s (%s, %s)", quote(probeTable), strings.Join(columns, ", "), def)); err != nil {
+			return false
+		}
+		if _, err := db.Exec(fmt.Sprintf("DROP TABLE temp.%s", quote(probeTable))); err != nil {
+			return false
+		}
+		return true
+	}
+}
+
+func quote(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
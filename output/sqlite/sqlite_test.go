@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := s.FindTableByName("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta.Constraints = append(ta.Constraints,
+		&schema.Constraint{Name: "a_a_check", Type: "CHECK", Def: "CHECK ((a > 0))"},
+		&schema.Constraint{Name: "a_a2_check", Type: "CHECK", Def: "CHECK ((char_length((a2)::text) > 4))"},
+	)
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+	db := open(t, got.Bytes())
+
+	tables := []string{}
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(tables, []string{CommentTable, "a", "b"}); diff != "" {
+		t.Error(diff)
+	}
+
+	// the CHECK constraints that SQLite can not create are omitted
+	if _, err := db.Exec("INSERT INTO a (a, a2) VALUES (0, 'a')"); err == nil {
+		t.Error("want CHECK constraint failed")
+	}
+	if _, err := db.Exec("INSERT INTO a (a, a2) VALUES (1, 'a')"); err != nil {
+		t.Error(err)
+	}
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO b (b, b2) VALUES (2, 'b')"); err == nil {
+		t.Error("want FOREIGN KEY constraint failed")
+	}
+
+	var comment string
+	if err := db.QueryRow("SELECT comment FROM " + CommentTable + " WHERE table_name = 'b' AND column_name = 'b2'").Scan(&comment); err != nil {
+		t.Fatal(err)
+	}
+	if want := "column b2"; comment != want {
+		t.Errorf("got %v\nwant %v", comment, want)
+	}
+	if err := db.QueryRow("SELECT comment FROM " + CommentTable + " WHERE table_name = 'a' AND column_name IS NULL").Scan(&comment); err != nil {
+		t.Fatal(err)
+	}
+	if want := "TABLE A"; comment != want {
+		t.Errorf("got %v\nwant %v", comment, want)
+	}
+}
+
+func TestOutputTable(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadConfigFile(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	tb, err := s.FindTableByName("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(c)
+	got := &bytes.Buffer{}
+	if err := o.OutputTable(got, tb); err != nil {
+		t.Fatal(err)
+	}
+	db := open(t, got.Bytes())
+	var count int
+	if err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'b'").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %v\nwant %v", count, 1)
+	}
+}
+
+func open(t *testing.T, b []byte) *sql.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "replica.sqlite3")
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
@@ -0,0 +1,91 @@
+package viewer
+
+import (
+	"embed"
+	"encoding/json"
+	"html"
+	"io"
+	"text/template"
+
+	"github.com/k1LoW/errors"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/samber/lo"
+)
+
+//go:embed templates/*
+var tmpl embed.FS
+
+// Viewer struct.
+type Viewer struct {
+	config *config.Config
+	tmpl   embed.FS
+}
+
+// Options is the options of the viewer embedded in the HTML with the schema.
+type Options struct {
+	Distance   int          `json:"distance"`
+	Viewpoints []*Viewpoint `json:"viewpoints"`
+}
+
+// Viewpoint is the viewpoint with the resolved table names.
+type Viewpoint struct {
+	Name   string   `json:"name"`
+	Desc   string   `json:"desc,omitempty"`
+	Tables []string `json:"tables"`
+}
+
+// New return Viewer.
+func New(c *config.Config) *Viewer {
+	return &Viewer{
+		config: c,
+		tmpl:   tmpl,
+	}
+}
+
+// OutputSchema output the interactive ER viewer as a single HTML file.
+// The schema is embedded in the same JSON format as schema.json, so the file works offline.
+func (v *Viewer) OutputSchema(wr io.Writer, s *schema.Schema) error {
+	sb, err := json.Marshal(s)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	distance := config.DefaultERDistance
+	if v.config.ER.Distance != nil {
+		distance = *v.config.ER.Distance
+	}
+	opts := &Options{
+		Distance:   distance,
+		Viewpoints: []*Viewpoint{},
+	}
+	for _, vp := range s.Viewpoints {
+		if vp.Schema == nil {
+			continue
+		}
+		opts.Viewpoints = append(opts.Viewpoints, &Viewpoint{
+			Name: vp.Name,
+			Desc: vp.Desc,
+			Tables: lo.Map(vp.Schema.Tables, func(t *schema.Table, _ int) string {
+				return t.Name
+			}),
+		})
+	}
+	ob, err := json.Marshal(opts)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	ts, err := v.tmpl.ReadFile("templates/viewer.html.tmpl")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tmpl := template.Must(template.New("viewer").Parse(string(ts)))
+	// json.Marshal escapes <, > and &, so the JSON can be embedded in <script> as is.
+	if err := tmpl.Execute(wr, map[string]interface{}{
+		"Title":   html.EscapeString(s.Name),
+		"Schema":  string(sb),
+		"Options": string(ob),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
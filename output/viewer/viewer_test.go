@@ -0,0 +1,95 @@
+package viewer
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/tbls/config"
+	"github.com/k1LoW/tbls/schema"
+	"github.com/k1LoW/tbls/testutil"
+)
+
+func TestOutputSchema(t *testing.T) {
+	s := testutil.NewSchema(t)
+	c, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Load(filepath.Join(testdataDir(), "out_test_tbls.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ModifySchema(s); err != nil {
+		t.Fatal(err)
+	}
+	v := New(c)
+	got := &bytes.Buffer{}
+	if err := v.OutputSchema(got, s); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		embedded := &schema.Schema{}
+		if err := json.Unmarshal(extract(t, got.String(), "tbls-schema"), embedded); err != nil {
+			t.Fatal(err)
+		}
+		if len(embedded.Tables) != len(s.Tables) {
+			t.Errorf("got %v\nwant %v", len(embedded.Tables), len(s.Tables))
+		}
+		if len(embedded.Relations) != len(s.Relations) {
+			t.Errorf("got %v\nwant %v", len(embedded.Relations), len(s.Relations))
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		opts := &Options{}
+		if err := json.Unmarshal(extract(t, got.String(), "tbls-options"), opts); err != nil {
+			t.Fatal(err)
+		}
+		if want := *c.ER.Distance; opts.Distance != want {
+			t.Errorf("got %v\nwant %v", opts.Distance, want)
+		}
+		want := []*Viewpoint{}
+		for _, vp := range s.Viewpoints {
+			tables := []string{}
+			for _, t := range vp.Schema.Tables {
+				tables = append(tables, t.Name)
+			}
+			want = append(want, &Viewpoint{Name: vp.Name, Desc: vp.Desc, Tables: tables})
+		}
+		if diff := cmp.Diff(opts.Viewpoints, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("escape", func(t *testing.T) {
+		s.Tables[0].Comment = "</script><script>alert(1)</script>"
+		got := &bytes.Buffer{}
+		if err := v.OutputSchema(got, s); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(got.Bytes(), []byte("<script>alert(1)")) {
+			t.Error("comment is not escaped")
+		}
+	})
+}
+
+func extract(t *testing.T, html, id string) []byte {
+	t.Helper()
+	re := regexp.MustCompile(`<script type="application/json" id="` + id + `">(.*)</script>`)
+	m := re.FindStringSubmatch(html)
+	if m == nil {
+		t.Fatalf("%s not found", id)
+	}
+	return []byte(m[1])
+}
+
+func testdataDir() string {
+	wd, _ := os.Getwd()
+	dir, _ := filepath.Abs(filepath.Join(filepath.Dir(filepath.Dir(wd)), "testdata"))
+	return dir
+}
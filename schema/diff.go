@@ -0,0 +1,810 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// ChangeAction is the kind of difference between two schemas.
+type ChangeAction string
+
+const (
+	ChangeActionAdded    ChangeAction = "added"
+	ChangeActionRemoved  ChangeAction = "removed"
+	ChangeActionModified ChangeAction = "modified"
+	ChangeActionRenamed  ChangeAction = "renamed"
+)
+
+// ChangeObject is the kind of schema object that is changed.
+type ChangeObject string
+
+const (
+	ChangeObjectTable      ChangeObject = "table"
+	ChangeObjectColumn     ChangeObject = "column"
+	ChangeObjectIndex      ChangeObject = "index"
+	ChangeObjectConstraint ChangeObject = "constraint"
+	ChangeObjectTrigger    ChangeObject = "trigger"
+	ChangeObjectRelation   ChangeObject = "relation"
+	ChangeObjectFunction   ChangeObject = "function"
+	ChangeObjectEnum       ChangeObject = "enum"
+	ChangeObjectViewpoint  ChangeObject = "viewpoint"
+)
+
+// ChangeClass is the compatibility class of a change.
+type ChangeClass string
+
+const (
+	// ChangeClassAdditive is a change that only adds objects.
+	ChangeClassAdditive ChangeClass = "additive"
+	// ChangeClassCompatible is a change that does not break existing clients.
+	ChangeClassCompatible ChangeClass = "compatible"
+	// ChangeClassBreaking is a change that may break existing clients.
+	ChangeClassBreaking ChangeClass = "breaking"
+)
+
+// ChangeClasses is the list of ChangeClass.
+var ChangeClasses = []ChangeClass{ChangeClassAdditive, ChangeClassCompatible, ChangeClassBreaking}
+
+// Change is the struct for a structural difference between two schemas.
+// For added and removed objects, From/To holds the definition of the object (the type for tables and columns).
+// For modified objects, Attribute is the name of the changed attribute and From/To hold its values.
+// For renamed objects, From/To hold the old and new names and Confidence holds the likelihood of the rename.
+// Table is the new name of the table for changes of a renamed table.
+type Change struct {
+	Action     ChangeAction `json:"action"`
+	Class      ChangeClass  `json:"class"`
+	Object     ChangeObject `json:"object"`
+	Table      string       `json:"table,omitempty"`
+	Name       string       `json:"name"`
+	Attribute  string       `json:"attribute,omitempty"`
+	From       string       `json:"from,omitempty"`
+	To         string       `json:"to,omitempty"`
+	Confidence float64      `json:"confidence,omitempty"`
+}
+
+// Changes is the list of Change.
+type Changes []*Change
+
+// Target return the qualified name of the changed object.
+func (c *Change) Target() string {
+	if c.Table == "" || c.Object == ChangeObjectTable || c.Object == ChangeObjectRelation {
+		return c.Name
+	}
+	return fmt.Sprintf("%s.%s", c.Table, c.Name)
+}
+
+// Filter return changes that match the object type.
+func (cs Changes) Filter(object ChangeObject) Changes {
+	filtered := Changes{}
+	for _, c := range cs {
+		if c.Object == object {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// Diff compares the schema objects of a and b, and returns the changes to turn a into b.
+// Renamed tables and columns are detected by the hints and heuristics (type, position, comment, constraint membership and name similarity).
+func Diff(a, b *Schema, hints ...*RenameHint) Changes {
+	changes := Changes{}
+	rn := newRenames()
+	changes = append(changes, diffTables(a, b, hints, rn)...)
+	changes = append(changes, diffRelations(a, b, rn)...)
+	changes = append(changes, diffFunctions(a, b)...)
+	changes = append(changes, diffEnums(a, b)...)
+	changes = append(changes, diffViewpoints(a, b)...)
+	for _, c := range changes {
+		c.Class = classifyChange(c, a, b, rn)
+	}
+	return changes
+}
+
+// HasClass return whether changes contain the class.
+func (cs Changes) HasClass(class ChangeClass) bool {
+	for _, c := range cs {
+		if c.Class == class {
+			return true
+		}
+	}
+	return false
+}
+
+func diffTables(a, b *Schema, hints []*RenameHint, rn *renames) Changes {
+	changes := Changes{}
+	removed := []*Table{}
+	for _, ta := range a.Tables {
+		if _, err := b.FindTableByName(ta.Name); err != nil {
+			removed = append(removed, ta)
+		}
+	}
+	added := []*Table{}
+	for _, tb := range b.Tables {
+		if _, err := a.FindTableByName(tb.Name); err != nil {
+			added = append(added, tb)
+		}
+	}
+	renamed := map[*Table]*Change{}
+	renamedTo := map[*Table]*Table{}
+	for _, p := range matchRenames(len(removed), len(added), func(i, j int) bool {
+		return lo.ContainsBy(hints, func(h *RenameHint) bool {
+			return h.Table == "" && a.NormalizeTableName(h.From) == a.NormalizeTableName(removed[i].Name) && b.NormalizeTableName(h.To) == b.NormalizeTableName(added[j].Name)
+		})
+	}, func(i, j int) float64 {
+		return tableRenameScore(removed[i], added[j])
+	}) {
+		ta, tb := removed[p.from], added[p.to]
+		rn.tables[ta.Name] = tb.Name
+		renamedTo[ta] = tb
+		renamed[tb] = &Change{
+			Action:     ChangeActionRenamed,
+			Object:     ChangeObjectTable,
+			Table:      tb.Name,
+			Name:       tb.Name,
+			From:       ta.Name,
+			To:         tb.Name,
+			Confidence: p.confidence,
+		}
+	}
+
+	for _, ta := range a.Tables {
+		if tb, ok := renamedTo[ta]; ok {
+			changes = append(changes, renamed[tb])
+			changes = append(changes, diffTable(ta, tb, hints, rn)...)
+			continue
+		}
+		tb, err := b.FindTableByName(ta.Name)
+		if err != nil {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectTable,
+				Table:  ta.Name,
+				Name:   ta.Name,
+				From:   ta.Type,
+			})
+			continue
+		}
+		changes = append(changes, diffTable(ta, tb, hints, rn)...)
+	}
+	for _, tb := range added {
+		if _, ok := renamed[tb]; ok {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectTable,
+			Table:  tb.Name,
+			Name:   tb.Name,
+			To:     tb.Type,
+		})
+	}
+	return changes
+}
+
+func diffTable(a, b *Table, hints []*RenameHint, rn *renames) Changes {
+	changes := Changes{}
+	changes = append(changes, diffAttributes(ChangeObjectTable, b.Name, b.Name, [][3]string{
+		{"type", a.Type, b.Type},
+		{"comment", a.Comment, b.Comment},
+		{"def", a.Def, b.Def},
+		{"labels", joinLabels(a.Labels), joinLabels(b.Labels)},
+	})...)
+
+	// columns
+	removed := []*Column{}
+	for _, ca := range a.Columns {
+		if _, err := b.FindColumnByName(ca.Name); err != nil {
+			removed = append(removed, ca)
+		}
+	}
+	added := []*Column{}
+	for _, cb := range b.Columns {
+		if _, err := a.FindColumnByName(cb.Name); err != nil {
+			added = append(added, cb)
+		}
+	}
+	renamedTo := map[*Column]*Column{}
+	renamed := map[*Column]*Change{}
+	for _, p := range matchRenames(len(removed), len(added), func(i, j int) bool {
+		return lo.ContainsBy(hints, func(h *RenameHint) bool {
+			return (h.Table == a.Name || h.Table == b.Name) && h.From == removed[i].Name && h.To == added[j].Name
+		})
+	}, func(i, j int) float64 {
+		return columnRenameScore(a, removed[i], b, added[j])
+	}) {
+		ca, cb := removed[p.from], added[p.to]
+		rn.addColumn(b.Name, ca.Name, cb.Name)
+		renamedTo[ca] = cb
+		renamed[cb] = &Change{
+			Action:     ChangeActionRenamed,
+			Object:     ChangeObjectColumn,
+			Table:      b.Name,
+			Name:       cb.Name,
+			From:       ca.Name,
+			To:         cb.Name,
+			Confidence: p.confidence,
+		}
+	}
+	for _, ca := range a.Columns {
+		cb, ok := renamedTo[ca]
+		if ok {
+			changes = append(changes, renamed[cb])
+		} else {
+			var err error
+			cb, err = b.FindColumnByName(ca.Name)
+			if err != nil {
+				changes = append(changes, &Change{
+					Action: ChangeActionRemoved,
+					Object: ChangeObjectColumn,
+					Table:  b.Name,
+					Name:   ca.Name,
+					From:   ca.Type,
+				})
+				continue
+			}
+		}
+		changes = append(changes, diffAttributes(ChangeObjectColumn, b.Name, cb.Name, [][3]string{
+			{"type", ca.Type, cb.Type},
+			{"nullable", strconv.FormatBool(ca.Nullable), strconv.FormatBool(cb.Nullable)},
+			{"default", columnDefault(ca), columnDefault(cb)},
+			{"extra_def", ca.ExtraDef, cb.ExtraDef},
+			{"comment", ca.Comment, cb.Comment},
+			{"labels", joinLabels(ca.Labels), joinLabels(cb.Labels)},
+		})...)
+	}
+	for _, cb := range added {
+		if _, ok := renamed[cb]; ok {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectColumn,
+			Table:  b.Name,
+			Name:   cb.Name,
+			To:     cb.Type,
+		})
+	}
+
+	// indexes
+	for _, ia := range a.Indexes {
+		ib, err := b.FindIndexByName(ia.Name)
+		if err != nil {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectIndex,
+				Table:  b.Name,
+				Name:   ia.Name,
+				From:   ia.Def,
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectIndex, b.Name, ia.Name, [][3]string{
+			{"def", ia.Def, ib.Def},
+			{"columns", strings.Join(ia.Columns, ", "), strings.Join(ib.Columns, ", ")},
+			{"comment", ia.Comment, ib.Comment},
+		})...)
+	}
+	for _, ib := range b.Indexes {
+		if _, err := a.FindIndexByName(ib.Name); err == nil {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectIndex,
+			Table:  b.Name,
+			Name:   ib.Name,
+			To:     ib.Def,
+		})
+	}
+
+	// constraints
+	for _, ca := range a.Constraints {
+		cb, err := b.FindConstraintByName(ca.Name)
+		if err != nil {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectConstraint,
+				Table:  b.Name,
+				Name:   ca.Name,
+				From:   ca.Def,
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectConstraint, b.Name, ca.Name, [][3]string{
+			{"type", ca.Type, cb.Type},
+			{"def", ca.Def, cb.Def},
+			{"columns", strings.Join(ca.Columns, ", "), strings.Join(cb.Columns, ", ")},
+			{"referenced_table", derefString(ca.ReferencedTable), derefString(cb.ReferencedTable)},
+			{"referenced_columns", strings.Join(ca.ReferencedColumns, ", "), strings.Join(cb.ReferencedColumns, ", ")},
+			{"comment", ca.Comment, cb.Comment},
+		})...)
+	}
+	for _, cb := range b.Constraints {
+		if _, err := a.FindConstraintByName(cb.Name); err == nil {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectConstraint,
+			Table:  b.Name,
+			Name:   cb.Name,
+			To:     cb.Def,
+		})
+	}
+
+	// triggers
+	for _, ta := range a.Triggers {
+		tb, err := b.FindTriggerByName(ta.Name)
+		if err != nil {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectTrigger,
+				Table:  b.Name,
+				Name:   ta.Name,
+				From:   ta.Def,
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectTrigger, b.Name, ta.Name, [][3]string{
+			{"def", ta.Def, tb.Def},
+			{"comment", ta.Comment, tb.Comment},
+		})...)
+	}
+	for _, tb := range b.Triggers {
+		if _, err := a.FindTriggerByName(tb.Name); err == nil {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectTrigger,
+			Table:  b.Name,
+			Name:   tb.Name,
+			To:     tb.Def,
+		})
+	}
+
+	return changes
+}
+
+func diffRelations(a, b *Schema, rn *renames) Changes {
+	changes := Changes{}
+	rbs := map[string]*Relation{}
+	for _, r := range b.Relations {
+		rbs[relationKey(r, nil)] = r
+	}
+	ras := map[string]*Relation{}
+	for _, ra := range a.Relations {
+		// relations of renamed tables and columns are compared by the new names
+		key := relationKey(ra, rn)
+		ras[key] = ra
+		rb, ok := rbs[key]
+		if !ok {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectRelation,
+				Table:  rn.table(ra.Table.Name),
+				Name:   key,
+				From:   ra.Def,
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectRelation, rb.Table.Name, key, [][3]string{
+			{"cardinality", ra.Cardinality.String(), rb.Cardinality.String()},
+			{"parent_cardinality", ra.ParentCardinality.String(), rb.ParentCardinality.String()},
+			{"def", ra.Def, rb.Def},
+			{"virtual", strconv.FormatBool(ra.Virtual), strconv.FormatBool(rb.Virtual)},
+		})...)
+	}
+	for _, rb := range b.Relations {
+		key := relationKey(rb, nil)
+		if _, ok := ras[key]; ok {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectRelation,
+			Table:  rb.Table.Name,
+			Name:   key,
+			To:     rb.Def,
+		})
+	}
+	return changes
+}
+
+func diffFunctions(a, b *Schema) Changes {
+	changes := Changes{}
+	fbs := map[string]*Function{}
+	for _, f := range b.Functions {
+		fbs[functionKey(f)] = f
+	}
+	fas := map[string]*Function{}
+	for _, fa := range a.Functions {
+		key := functionKey(fa)
+		fas[key] = fa
+		fb, ok := fbs[key]
+		if !ok {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectFunction,
+				Name:   key,
+				From:   fa.ReturnType,
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectFunction, "", key, [][3]string{
+			{"return_type", fa.ReturnType, fb.ReturnType},
+			{"type", fa.Type, fb.Type},
+			{"def", fa.Def, fb.Def},
+		})...)
+	}
+	for _, fb := range b.Functions {
+		key := functionKey(fb)
+		if _, ok := fas[key]; ok {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectFunction,
+			Name:   key,
+			To:     fb.ReturnType,
+		})
+	}
+	return changes
+}
+
+func diffEnums(a, b *Schema) Changes {
+	changes := Changes{}
+	ebs := map[string]*Enum{}
+	for _, e := range b.Enums {
+		ebs[e.Name] = e
+	}
+	eas := map[string]*Enum{}
+	for _, ea := range a.Enums {
+		eas[ea.Name] = ea
+		eb, ok := ebs[ea.Name]
+		if !ok {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectEnum,
+				Name:   ea.Name,
+				From:   strings.Join(ea.Values, ", "),
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectEnum, "", ea.Name, [][3]string{
+			{"values", strings.Join(ea.Values, ", "), strings.Join(eb.Values, ", ")},
+		})...)
+	}
+	for _, eb := range b.Enums {
+		if _, ok := eas[eb.Name]; ok {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectEnum,
+			Name:   eb.Name,
+			To:     strings.Join(eb.Values, ", "),
+		})
+	}
+	return changes
+}
+
+func diffViewpoints(a, b *Schema) Changes {
+	changes := Changes{}
+	vbs := map[string]*Viewpoint{}
+	for _, v := range b.Viewpoints {
+		vbs[v.Name] = v
+	}
+	vas := map[string]*Viewpoint{}
+	for _, va := range a.Viewpoints {
+		vas[va.Name] = va
+		vb, ok := vbs[va.Name]
+		if !ok {
+			changes = append(changes, &Change{
+				Action: ChangeActionRemoved,
+				Object: ChangeObjectViewpoint,
+				Name:   va.Name,
+				From:   va.Desc,
+			})
+			continue
+		}
+		changes = append(changes, diffAttributes(ChangeObjectViewpoint, "", va.Name, [][3]string{
+			{"desc", va.Desc, vb.Desc},
+			{"tables", strings.Join(va.Tables, ", "), strings.Join(vb.Tables, ", ")},
+			{"labels", strings.Join(va.Labels, ", "), strings.Join(vb.Labels, ", ")},
+			{"distance", strconv.Itoa(va.Distance), strconv.Itoa(vb.Distance)},
+			{"groups", joinViewpointGroups(va.Groups), joinViewpointGroups(vb.Groups)},
+		})...)
+	}
+	for _, vb := range b.Viewpoints {
+		if _, ok := vas[vb.Name]; ok {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action: ChangeActionAdded,
+			Object: ChangeObjectViewpoint,
+			Name:   vb.Name,
+			To:     vb.Desc,
+		})
+	}
+	return changes
+}
+
+// diffAttributes compares attributes given as {name, value of a, value of b}.
+func diffAttributes(object ChangeObject, table, name string, attrs [][3]string) Changes {
+	changes := Changes{}
+	for _, attr := range attrs {
+		if attr[1] == attr[2] {
+			continue
+		}
+		changes = append(changes, &Change{
+			Action:    ChangeActionModified,
+			Object:    object,
+			Table:     table,
+			Name:      name,
+			Attribute: attr[0],
+			From:      attr[1],
+			To:        attr[2],
+		})
+	}
+	return changes
+}
+
+// classifyChange classifies the change of a into b.
+func classifyChange(c *Change, a, b *Schema, rn *renames) ChangeClass {
+	switch c.Action {
+	case ChangeActionRenamed:
+		// existing queries refer to the old name
+		return ChangeClassBreaking
+	case ChangeActionAdded:
+		switch c.Object {
+		case ChangeObjectColumn:
+		case ChangeObjectConstraint, ChangeObjectIndex:
+			// a constraint or a unique index added to an existing table can reject existing rows and future writes
+			if !existedTable(a, c.Table, rn) {
+				return ChangeClassAdditive
+			}
+			if c.Object == ChangeObjectIndex {
+				if strings.Contains(strings.ToUpper(c.To), "UNIQUE") {
+					return ChangeClassBreaking
+				}
+				return ChangeClassAdditive
+			}
+			t, err := b.FindTableByName(c.Table)
+			if err != nil {
+				return ChangeClassBreaking
+			}
+			for _, con := range t.Constraints {
+				if con.Name != c.Name {
+					continue
+				}
+				switch strings.ToUpper(con.Type) {
+				case "UNIQUE", "CHECK", "FOREIGN KEY", "PRIMARY KEY", "EXCLUSION":
+					return ChangeClassBreaking
+				}
+				return ChangeClassCompatible
+			}
+			return ChangeClassBreaking
+		default:
+			return ChangeClassAdditive
+		}
+		// a NOT NULL column without default value breaks existing INSERT statements
+		t, err := b.FindTableByName(c.Table)
+		if err != nil {
+			return ChangeClassAdditive
+		}
+		col, err := t.FindColumnByName(c.Name)
+		if err != nil || col.Nullable || col.Default.Valid || col.ExtraDef != "" {
+			return ChangeClassAdditive
+		}
+		return ChangeClassBreaking
+	case ChangeActionRemoved:
+		switch c.Object {
+		case ChangeObjectTable, ChangeObjectColumn, ChangeObjectFunction, ChangeObjectEnum:
+			return ChangeClassBreaking
+		case ChangeObjectRelation:
+			// the relation is removed because the referenced table or columns no longer exist
+			for _, r := range a.Relations {
+				if relationKey(r, rn) != c.Name {
+					continue
+				}
+				pt, err := b.FindTableByName(rn.table(r.ParentTable.Name))
+				if err != nil {
+					return ChangeClassBreaking
+				}
+				for _, pc := range r.ParentColumns {
+					if _, err := pt.FindColumnByName(rn.column(r.ParentTable.Name, pc.Name)); err != nil {
+						return ChangeClassBreaking
+					}
+				}
+			}
+			return ChangeClassCompatible
+		default:
+			return ChangeClassCompatible
+		}
+	default:
+		switch {
+		case c.Object == ChangeObjectTable && c.Attribute == "type":
+			return ChangeClassBreaking
+		case c.Object == ChangeObjectColumn && c.Attribute == "type":
+			if isWidenedType(c.From, c.To) {
+				return ChangeClassCompatible
+			}
+			return ChangeClassBreaking
+		case c.Object == ChangeObjectColumn && c.Attribute == "nullable":
+			if c.To == "false" {
+				return ChangeClassBreaking
+			}
+			return ChangeClassCompatible
+		case c.Object == ChangeObjectConstraint && (c.Attribute == "type" || c.Attribute == "columns" || c.Attribute == "referenced_table" || c.Attribute == "referenced_columns"):
+			return ChangeClassBreaking
+		case c.Object == ChangeObjectFunction && c.Attribute == "return_type":
+			return ChangeClassBreaking
+		case c.Object == ChangeObjectEnum && c.Attribute == "values":
+			to := strings.Split(c.To, ", ")
+			for _, v := range strings.Split(c.From, ", ") {
+				if !lo.Contains(to, v) {
+					return ChangeClassBreaking
+				}
+			}
+			return ChangeClassAdditive
+		default:
+			return ChangeClassCompatible
+		}
+	}
+}
+
+// existedTable return whether the table (new name) exists in schema a.
+func existedTable(a *Schema, name string, rn *renames) bool {
+	for _, t := range a.Tables {
+		if rn.table(t.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+var columnTypeRe = regexp.MustCompile(`^([a-z](?:[a-z0-9_ ]*[a-z0-9_])?)\s*(?:\(([^)]*)\))?\s*(.*)$`)
+
+// typeFamilies is the list of column types that can be widened in order of size.
+var typeFamilies = [][]string{
+	{"tinyint", "smallint|int2|smallserial", "mediumint", "int|integer|int4|serial", "bigint|int8|bigserial"},
+	{"real|float4", "float|double|double precision|float8"},
+	{"char|character|nchar", "varchar|character varying|nvarchar|varchar2", "text|ntext|tinytext", "mediumtext", "longtext"},
+	// timestamps with and without time zone are not in the same family, because stored values are interpreted differently
+	{"timestamp|timestamp without time zone"},
+	{"timestamptz|timestamp with time zone"},
+	{"datetime", "datetime2"},
+}
+
+// isWidenedType return whether values of the from type can always be stored in the to type.
+func isWidenedType(from, to string) bool {
+	fm := columnTypeRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(from)))
+	tm := columnTypeRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(to)))
+	if fm == nil || tm == nil {
+		return false
+	}
+	if fm[3] != tm[3] {
+		return false
+	}
+	if fm[1] == tm[1] {
+		return isWidenedParams(fm[2], tm[2])
+	}
+	for _, family := range typeFamilies {
+		fr, tr := -1, -1
+		for i, names := range family {
+			for _, n := range strings.Split(names, "|") {
+				if n == fm[1] {
+					fr = i
+				}
+				if n == tm[1] {
+					tr = i
+				}
+			}
+		}
+		if fr < 0 || tr < 0 {
+			continue
+		}
+		if fr > tr {
+			return false
+		}
+		if tm[2] == "" {
+			return true
+		}
+		return isWidenedParams(fm[2], tm[2])
+	}
+	return false
+}
+
+// isWidenedParams compares type parameters such as length, precision and scale.
+func isWidenedParams(from, to string) bool {
+	if to == "" || strings.EqualFold(to, "max") {
+		return true
+	}
+	if from == "" || strings.EqualFold(from, "max") {
+		return false
+	}
+	fps, ok := atois(strings.Split(from, ","))
+	if !ok {
+		return false
+	}
+	tps, ok := atois(strings.Split(to, ","))
+	if !ok || len(fps) != len(tps) {
+		return false
+	}
+	if len(fps) == 2 {
+		// precision and scale: both the integer digits and the fractional digits must not decrease
+		return tps[0]-tps[1] >= fps[0]-fps[1] && tps[1] >= fps[1]
+	}
+	for i := range fps {
+		if tps[i] < fps[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func atois(ss []string) ([]int, bool) {
+	is := []int{}
+	for _, s := range ss {
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, false
+		}
+		is = append(is, i)
+	}
+	return is, true
+}
+
+func relationKey(r *Relation, rn *renames) string {
+	if rn == nil {
+		rn = newRenames()
+	}
+	columns := []string{}
+	for _, c := range r.Columns {
+		columns = append(columns, rn.column(r.Table.Name, c.Name))
+	}
+	parentColumns := []string{}
+	for _, c := range r.ParentColumns {
+		parentColumns = append(parentColumns, rn.column(r.ParentTable.Name, c.Name))
+	}
+	return fmt.Sprintf("%s(%s) -> %s(%s)", rn.table(r.Table.Name), strings.Join(columns, ", "), rn.table(r.ParentTable.Name), strings.Join(parentColumns, ", "))
+}
+
+func functionKey(f *Function) string {
+	return fmt.Sprintf("%s(%s)", f.Name, f.Arguments)
+}
+
+func columnDefault(c *Column) string {
+	if !c.Default.Valid {
+		return ""
+	}
+	return c.Default.String
+}
+
+func joinLabels(labels Labels) string {
+	names := []string{}
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinViewpointGroups(groups []*ViewpointGroup) string {
+	names := []string{}
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
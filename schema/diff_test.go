@@ -0,0 +1,318 @@
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *Schema)
+		want   Changes
+	}{
+		{
+			"no changes",
+			func(s *Schema) {},
+			Changes{},
+		},
+		{
+			"add column",
+			func(s *Schema) {
+				s.Tables[0].Columns = append(s.Tables[0].Columns, &Column{Name: "a3", Type: "text", Nullable: true})
+			},
+			Changes{
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectColumn, Table: "a", Name: "a3", To: "text"},
+			},
+		},
+		{
+			"drop column",
+			func(s *Schema) {
+				s.Tables[1].Columns = s.Tables[1].Columns[:1]
+			},
+			Changes{
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b2", From: "text"},
+			},
+		},
+		{
+			"modify column",
+			func(s *Schema) {
+				s.Tables[1].Columns[1].Type = "varchar(255)"
+				s.Tables[1].Columns[1].Nullable = false
+				s.Tables[1].Columns[1].Default = sql.NullString{String: "''", Valid: true}
+			},
+			Changes{
+				{Action: ChangeActionModified, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b2", Attribute: "type", From: "text", To: "varchar(255)"},
+				{Action: ChangeActionModified, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b2", Attribute: "nullable", From: "true", To: "false"},
+				{Action: ChangeActionModified, Class: ChangeClassCompatible, Object: ChangeObjectColumn, Table: "b", Name: "b2", Attribute: "default", From: "", To: "''"},
+			},
+		},
+		{
+			"add and drop table",
+			func(s *Schema) {
+				s.Tables = append(s.Tables, &Table{Name: "c", Type: "BASE TABLE"})
+				s.Tables[0].Comment = "table a (modified)"
+			},
+			Changes{
+				{Action: ChangeActionModified, Class: ChangeClassCompatible, Object: ChangeObjectTable, Table: "a", Name: "a", Attribute: "comment", From: "table a", To: "table a (modified)"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectTable, Table: "c", Name: "c", To: "BASE TABLE"},
+			},
+		},
+		{
+			"add not null column without default",
+			func(s *Schema) {
+				s.Tables[0].Columns = append(s.Tables[0].Columns, &Column{Name: "a3", Type: "text", Nullable: false})
+			},
+			Changes{
+				{Action: ChangeActionAdded, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "a", Name: "a3", To: "text"},
+			},
+		},
+		{
+			"add constraints to existing table",
+			func(s *Schema) {
+				s.Tables[0].Constraints = append(s.Tables[0].Constraints,
+					&Constraint{Name: "a_a_key", Type: "UNIQUE", Def: "UNIQUE (a)"},
+					&Constraint{Name: "a_a_check", Type: "CHECK", Def: "CHECK ((a > 0))"},
+					&Constraint{Name: "a_a_fkey", Type: "FOREIGN KEY", Def: "FOREIGN KEY (a) REFERENCES b(b)"},
+					&Constraint{Name: "a_trigger", Type: "TRIGGER", Def: "CREATE CONSTRAINT TRIGGER a_trigger"},
+				)
+			},
+			Changes{
+				{Action: ChangeActionAdded, Class: ChangeClassBreaking, Object: ChangeObjectConstraint, Table: "a", Name: "a_a_key", To: "UNIQUE (a)"},
+				{Action: ChangeActionAdded, Class: ChangeClassBreaking, Object: ChangeObjectConstraint, Table: "a", Name: "a_a_check", To: "CHECK ((a > 0))"},
+				{Action: ChangeActionAdded, Class: ChangeClassBreaking, Object: ChangeObjectConstraint, Table: "a", Name: "a_a_fkey", To: "FOREIGN KEY (a) REFERENCES b(b)"},
+				{Action: ChangeActionAdded, Class: ChangeClassCompatible, Object: ChangeObjectConstraint, Table: "a", Name: "a_trigger", To: "CREATE CONSTRAINT TRIGGER a_trigger"},
+			},
+		},
+		{
+			"add indexes to existing table",
+			func(s *Schema) {
+				s.Tables[0].Indexes = append(s.Tables[0].Indexes,
+					&Index{Name: "a_a_idx", Def: "CREATE UNIQUE INDEX a_a_idx ON public.a USING btree (a)"},
+					&Index{Name: "a_a2_idx", Def: "CREATE INDEX a_a2_idx ON public.a USING btree (a2)"},
+				)
+			},
+			Changes{
+				{Action: ChangeActionAdded, Class: ChangeClassBreaking, Object: ChangeObjectIndex, Table: "a", Name: "a_a_idx", To: "CREATE UNIQUE INDEX a_a_idx ON public.a USING btree (a)"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectIndex, Table: "a", Name: "a_a2_idx", To: "CREATE INDEX a_a2_idx ON public.a USING btree (a2)"},
+			},
+		},
+		{
+			"add constraint to renamed table",
+			func(s *Schema) {
+				s.Tables[1].Name = "b_renamed"
+				s.Tables[1].Constraints = append(s.Tables[1].Constraints, &Constraint{Name: "b_b_key", Type: "UNIQUE", Def: "UNIQUE (b)"})
+			},
+			Changes{
+				{Action: ChangeActionRenamed, Class: ChangeClassBreaking, Object: ChangeObjectTable, Table: "b_renamed", Name: "b_renamed", From: "b", To: "b_renamed", Confidence: 0.88},
+				{Action: ChangeActionAdded, Class: ChangeClassBreaking, Object: ChangeObjectConstraint, Table: "b_renamed", Name: "b_b_key", To: "UNIQUE (b)"},
+			},
+		},
+		{
+			"drop relation with parent column",
+			func(s *Schema) {
+				s.Tables[1].Columns = s.Tables[1].Columns[1:]
+				s.Relations = nil
+			},
+			Changes{
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b", From: "text"},
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectRelation, Table: "a", Name: "a(a) -> b(b)"},
+			},
+		},
+		{
+			"drop relation",
+			func(s *Schema) {
+				s.Relations = nil
+			},
+			Changes{
+				{Action: ChangeActionRemoved, Class: ChangeClassCompatible, Object: ChangeObjectRelation, Table: "a", Name: "a(a) -> b(b)"},
+			},
+		},
+		{
+			"rename column",
+			func(s *Schema) {
+				s.Tables[1].Columns[1].Name = "b3"
+			},
+			Changes{
+				{Action: ChangeActionRenamed, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b3", From: "b2", To: "b3", Confidence: 0.9},
+			},
+		},
+		{
+			"rename and modify column",
+			func(s *Schema) {
+				s.Tables[1].Columns[1].Name = "b3"
+				s.Tables[1].Columns[1].Nullable = false
+			},
+			Changes{
+				{Action: ChangeActionRenamed, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b3", From: "b2", To: "b3", Confidence: 0.9},
+				{Action: ChangeActionModified, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b3", Attribute: "nullable", From: "true", To: "false"},
+			},
+		},
+		{
+			"rename table",
+			func(s *Schema) {
+				s.Tables[1].Name = "b_renamed"
+			},
+			Changes{
+				{Action: ChangeActionRenamed, Class: ChangeClassBreaking, Object: ChangeObjectTable, Table: "b_renamed", Name: "b_renamed", From: "b", To: "b_renamed", Confidence: 0.88},
+			},
+		},
+		{
+			"drop and add unrelated column",
+			func(s *Schema) {
+				s.Tables[1].Columns[1] = &Column{Name: "created", Type: "timestamp", Nullable: true}
+			},
+			Changes{
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b2", From: "text"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectColumn, Table: "b", Name: "created", To: "timestamp"},
+			},
+		},
+		{
+			"drop and add column with unrelated name and same definition",
+			func(s *Schema) {
+				s.Tables[1].Columns[1] = &Column{Name: "is_active", Type: "text", Nullable: true}
+			},
+			Changes{
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "b", Name: "b2", From: "text"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectColumn, Table: "b", Name: "is_active", To: "text"},
+			},
+		},
+		{
+			"drop and add table with unrelated name and same columns",
+			func(s *Schema) {
+				s.Tables[1].Name = "invoices"
+				s.Tables[1].Comment = ""
+			},
+			Changes{
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectTable, Table: "b", Name: "b", From: "BASE TABLE"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectTable, Table: "invoices", Name: "invoices", To: "BASE TABLE"},
+				{Action: ChangeActionRemoved, Class: ChangeClassBreaking, Object: ChangeObjectRelation, Table: "a", Name: "a(a) -> b(b)"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectRelation, Table: "a", Name: "a(a) -> invoices(b)"},
+			},
+		},
+		{
+			"functions, enums and viewpoints",
+			func(s *Schema) {
+				s.Functions = []*Function{{Name: "f", Arguments: "x integer", ReturnType: "integer"}}
+				s.Enums = []*Enum{{Name: "e", Values: []string{"one"}}}
+				s.Viewpoints = Viewpoints{{Name: "v", Desc: "viewpoint"}}
+			},
+			Changes{
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectFunction, Name: "f(x integer)", To: "integer"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectEnum, Name: "e", To: "one"},
+				{Action: ChangeActionAdded, Class: ChangeClassAdditive, Object: ChangeObjectViewpoint, Name: "v", To: "viewpoint"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestSchema(t)
+			b := newTestSchema(t)
+			tt.modify(b)
+			got := Diff(a, b)
+			if diff := cmp.Diff(got, tt.want, nil); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDiffWithRenameHints(t *testing.T) {
+	a := newTestSchema(t)
+	b := newTestSchema(t)
+	b.Tables[1].Columns[1] = &Column{Name: "description", Type: "varchar(255)", Nullable: true}
+	b.Tables[1].Name = "c"
+	hints := []*RenameHint{
+		{From: "b", To: "c"},
+		{Table: "c", From: "b2", To: "description"},
+	}
+	got := Diff(a, b, hints...)
+	want := Changes{
+		{Action: ChangeActionRenamed, Class: ChangeClassBreaking, Object: ChangeObjectTable, Table: "c", Name: "c", From: "b", To: "c", Confidence: 1},
+		{Action: ChangeActionRenamed, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "c", Name: "description", From: "b2", To: "description", Confidence: 1},
+		{Action: ChangeActionModified, Class: ChangeClassBreaking, Object: ChangeObjectColumn, Table: "c", Name: "description", Attribute: "type", From: "text", To: "varchar(255)"},
+		{Action: ChangeActionModified, Class: ChangeClassCompatible, Object: ChangeObjectColumn, Table: "c", Name: "description", Attribute: "comment", From: "column b2"},
+	}
+	if diff := cmp.Diff(got, want, nil); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want float64
+	}{
+		{"email", "email", 1},
+		{"mail", "email", 0.8},
+		{"user_id", "USER_ID", 1},
+		{"abc", "xyz", 0},
+		{"b", "b_renamed", 0.5},
+		{"tags", "invoices", 0.125},
+		{"legacy_flag", "is_active", 0.18181818181818177},
+		{"", "", 1},
+	}
+	for _, tt := range tests {
+		if got := nameSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s, %s: got %v\nwant %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestChangeTarget(t *testing.T) {
+	tests := []struct {
+		c    *Change
+		want string
+	}{
+		{&Change{Object: ChangeObjectTable, Table: "a", Name: "a"}, "a"},
+		{&Change{Object: ChangeObjectColumn, Table: "a", Name: "a2"}, "a.a2"},
+		{&Change{Object: ChangeObjectRelation, Table: "a", Name: "a(a) -> b(b)"}, "a(a) -> b(b)"},
+		{&Change{Object: ChangeObjectEnum, Name: "e"}, "e"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Target(); got != tt.want {
+			t.Errorf("got %v\nwant %v", got, tt.want)
+		}
+	}
+}
+
+func TestIsWidenedType(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{"varchar(10)", "varchar(20)", true},
+		{"varchar(20)", "varchar(10)", false},
+		{"varchar(20)", "text", true},
+		{"text", "varchar(20)", false},
+		{"character varying(20)", "character varying(255)", true},
+		{"varchar(255)", "varchar(max)", true},
+		{"int", "bigint", true},
+		{"bigint", "integer", false},
+		{"int(11) unsigned", "bigint(20) unsigned", true},
+		{"int(11) unsigned", "int(11)", false},
+		{"decimal(10,2)", "decimal(12,2)", true},
+		{"decimal(10,2)", "decimal(10,1)", false},
+		{"decimal(10,2)", "decimal(10,4)", false},
+		{"decimal(10,2)", "decimal(12,4)", true},
+		{"timestamp", "timestamptz", false},
+		{"timestamp without time zone", "timestamp", true},
+		{"datetime", "datetimeoffset", false},
+		{"datetime", "datetime2", true},
+		{"datetime", "timestamp", false},
+		{"integer", "text", false},
+		{"float", "double precision", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s -> %s", tt.from, tt.to), func(t *testing.T) {
+			if got := isWidenedType(tt.from, tt.to); got != tt.want {
+				t.Errorf("got %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LintIgnoreMarker is the marker in comments to ignore lint rules for the table or the column.
+// `tbls:lint-ignore requireColumnComment,columnCount` ignores the rules, and `tbls:lint-ignore` ignores all rules.
+const LintIgnoreMarker = "tbls:lint-ignore"
+
+var lintIgnoreRe = regexp.MustCompile(`[ \t]*` + regexp.QuoteMeta(LintIgnoreMarker) + `\b([^\r\n]*)`)
+
+// ParseLintIgnore return the comment without the lint-ignore markers and the rules to be ignored.
+// Lines that contain only the marker are removed.
+func ParseLintIgnore(comment string) (string, []string) {
+	if !strings.Contains(comment, LintIgnoreMarker) {
+		return comment, nil
+	}
+	var rules []string
+	lines := []string{}
+	for _, l := range strings.Split(comment, "\n") {
+		m := lintIgnoreRe.FindStringSubmatch(l)
+		if m == nil {
+			lines = append(lines, l)
+			continue
+		}
+		all := true
+		for _, r := range strings.Split(m[1], ",") {
+			r = strings.TrimSpace(r)
+			if r == "" {
+				continue
+			}
+			all = false
+			rules = append(rules, r)
+		}
+		if all {
+			rules = append(rules, "*")
+		}
+		if l = lintIgnoreRe.ReplaceAllString(l, ""); strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), rules
+}
+
+// ExtractLintIgnore moves the lint-ignore markers in the comments of tables and columns to LintIgnore.
+func (s *Schema) ExtractLintIgnore() {
+	for _, t := range s.Tables {
+		var rules []string
+		t.Comment, rules = ParseLintIgnore(t.Comment)
+		t.LintIgnore = append(t.LintIgnore, rules...)
+		for _, c := range t.Columns {
+			c.Comment, rules = ParseLintIgnore(c.Comment)
+			c.LintIgnore = append(c.LintIgnore, rules...)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLintIgnore(t *testing.T) {
+	tests := []struct {
+		comment     string
+		wantComment string
+		wantRules   []string
+	}{
+		{"users table", "users table", nil},
+		{"users table tbls:lint-ignore requireColumnComment,columnCount", "users table", []string{"requireColumnComment", "columnCount"}},
+		{"users table\ntbls:lint-ignore requireColumnComment, columnCount\nsecond line", "users table\nsecond line", []string{"requireColumnComment", "columnCount"}},
+		{"tbls:lint-ignore", "", []string{"*"}},
+		{"tbls:lint-ignore columnCount\ntbls:lint-ignore", "", []string{"columnCount", "*"}},
+		{"tbls:lint-ignored", "tbls:lint-ignored", nil},
+	}
+	for _, tt := range tests {
+		gotComment, gotRules := ParseLintIgnore(tt.comment)
+		if gotComment != tt.wantComment {
+			t.Errorf("got %q\nwant %q", gotComment, tt.wantComment)
+		}
+		if diff := cmp.Diff(gotRules, tt.wantRules); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestExtractLintIgnore(t *testing.T) {
+	s := &Schema{
+		Tables: []*Table{
+			{
+				Name:    "users",
+				Comment: "users table tbls:lint-ignore columnCount",
+				Columns: []*Column{
+					{Name: "id", Comment: "tbls:lint-ignore requireColumnComment"},
+					{Name: "name", Comment: "user name"},
+				},
+			},
+		},
+	}
+	s.ExtractLintIgnore()
+	if want := "users table"; s.Tables[0].Comment != want {
+		t.Errorf("got %q\nwant %q", s.Tables[0].Comment, want)
+	}
+	if diff := cmp.Diff(s.Tables[0].LintIgnore, []string{"columnCount"}); diff != "" {
+		t.Error(diff)
+	}
+	if s.Tables[0].Columns[0].Comment != "" {
+		t.Errorf("got %q\nwant %q", s.Tables[0].Columns[0].Comment, "")
+	}
+	if diff := cmp.Diff(s.Tables[0].Columns[0].LintIgnore, []string{"requireColumnComment"}); diff != "" {
+		t.Error(diff)
+	}
+	if s.Tables[0].Columns[1].LintIgnore != nil {
+		t.Errorf("got %v\nwant %v", s.Tables[0].Columns[1].LintIgnore, nil)
+	}
+}
@@ -0,0 +1,229 @@
+package schema
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+// renameThreshold is the minimum confidence to detect a pair of removed and added objects as renamed.
+const renameThreshold = 0.6
+
+// renameNameThreshold is the minimum name similarity to detect a pair of removed and added objects as renamed.
+// Objects with unrelated names are never detected as renamed however similar their definitions are.
+const renameNameThreshold = 0.5
+
+// RenameHint is the hint of a renamed table or column for Diff.
+type RenameHint struct {
+	// Table is the name of the table of the renamed column. Empty for a renamed table.
+	Table string `json:"table,omitempty" yaml:"table,omitempty"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+// renames is the mapping of renamed tables and columns.
+type renames struct {
+	tables  map[string]string
+	columns map[string]map[string]string
+}
+
+type renamePair struct {
+	from       int
+	to         int
+	confidence float64
+}
+
+func newRenames() *renames {
+	return &renames{
+		tables:  map[string]string{},
+		columns: map[string]map[string]string{},
+	}
+}
+
+// table return the new name of the table.
+func (rn *renames) table(name string) string {
+	if to, ok := rn.tables[name]; ok {
+		return to
+	}
+	return name
+}
+
+// column return the new name of the column of the table (old name).
+func (rn *renames) column(table, name string) string {
+	if cs, ok := rn.columns[rn.table(table)]; ok {
+		if to, ok := cs[name]; ok {
+			return to
+		}
+	}
+	return name
+}
+
+func (rn *renames) addColumn(table, from, to string) {
+	if _, ok := rn.columns[table]; !ok {
+		rn.columns[table] = map[string]string{}
+	}
+	rn.columns[table][from] = to
+}
+
+// matchRenames return pairs of removed (from) and added (to) objects detected as renamed.
+// Pairs by hints take precedence, and the rest are paired in descending order of the score.
+func matchRenames(nFrom, nTo int, hinted func(i, j int) bool, score func(i, j int) float64) []renamePair {
+	pairs := []renamePair{}
+	candidates := []renamePair{}
+	for i := 0; i < nFrom; i++ {
+		for j := 0; j < nTo; j++ {
+			if hinted(i, j) {
+				pairs = append(pairs, renamePair{from: i, to: j, confidence: 1})
+				continue
+			}
+			if s := score(i, j); s >= renameThreshold {
+				candidates = append(candidates, renamePair{from: i, to: j, confidence: math.Round(s*100) / 100})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(x, y int) bool {
+		return candidates[x].confidence > candidates[y].confidence
+	})
+	usedFrom := map[int]struct{}{}
+	usedTo := map[int]struct{}{}
+	matched := []renamePair{}
+	for _, p := range append(pairs, candidates...) {
+		if _, ok := usedFrom[p.from]; ok {
+			continue
+		}
+		if _, ok := usedTo[p.to]; ok {
+			continue
+		}
+		usedFrom[p.from] = struct{}{}
+		usedTo[p.to] = struct{}{}
+		matched = append(matched, p)
+	}
+	sort.SliceStable(matched, func(x, y int) bool {
+		return matched[x].from < matched[y].from
+	})
+	return matched
+}
+
+// tableRenameScore return the likelihood that table a is renamed to table b.
+func tableRenameScore(a, b *Table) float64 {
+	similarity := nameSimilarity(a.Name, b.Name)
+	if similarity < renameNameThreshold {
+		return 0
+	}
+	columns := func(t *Table) []string {
+		return lo.Map(t.Columns, func(c *Column, _ int) string {
+			return strings.ToLower(c.Name + " " + c.Type)
+		})
+	}
+	ca := columns(a)
+	cb := columns(b)
+	union := lo.Union(ca, cb)
+	if len(union) == 0 {
+		return 0
+	}
+	jaccard := float64(len(lo.Intersect(ca, cb))) / float64(len(union))
+	if jaccard == 0 {
+		return 0
+	}
+	score := 0.5 * jaccard
+	if a.Comment != "" && a.Comment == b.Comment {
+		score += 0.15
+	}
+	if a.Type == b.Type {
+		score += 0.1
+	}
+	score += 0.25 * similarity
+	return score
+}
+
+// columnRenameScore return the likelihood that column a of table ta is renamed to column b of table tb.
+func columnRenameScore(ta *Table, a *Column, tb *Table, b *Column) float64 {
+	similarity := nameSimilarity(a.Name, b.Name)
+	if similarity < renameNameThreshold {
+		return 0
+	}
+	score := 0.0
+	switch {
+	case strings.EqualFold(a.Type, b.Type):
+		score += 0.35
+	case isWidenedType(a.Type, b.Type):
+		score += 0.25
+	}
+	ia := lo.IndexOf(ta.Columns, a)
+	ib := lo.IndexOf(tb.Columns, b)
+	switch {
+	case ia == ib:
+		score += 0.15
+	case ia-ib == 1 || ib-ia == 1:
+		score += 0.075
+	}
+	if a.Comment != "" && a.Comment == b.Comment {
+		score += 0.2
+	}
+	if constraintMembership(ta, a.Name) == constraintMembership(tb, b.Name) {
+		score += 0.1
+	}
+	score += 0.2 * similarity
+	return score
+}
+
+// constraintMembership return the types of constraints that contain the column.
+func constraintMembership(t *Table, column string) string {
+	types := lo.Uniq(lo.Map(t.FindConstrainsByColumnName(column), func(c *Constraint, _ int) string {
+		return c.Type
+	}))
+	sort.Strings(types)
+	return strings.Join(types, ", ")
+}
+
+// nameSimilarity return the similarity of names.
+// It is the higher of the similarity based on the Levenshtein distance and the ratio of shared words (e.g. b and b_renamed).
+func nameSimilarity(a, b string) float64 {
+	return max(levenshteinSimilarity(a, b), wordSimilarity(a, b))
+}
+
+// wordSimilarity return the Jaccard index of the words of names separated by non-alphanumeric characters.
+func wordSimilarity(a, b string) float64 {
+	words := func(name string) []string {
+		return lo.Uniq(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}))
+	}
+	wa := words(a)
+	wb := words(b)
+	union := lo.Union(wa, wb)
+	if len(union) == 0 {
+		return 0
+	}
+	return float64(len(lo.Intersect(wa, wb))) / float64(len(union))
+}
+
+// levenshteinSimilarity return the similarity of names based on the Levenshtein distance.
+func levenshteinSimilarity(a, b string) float64 {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+	l := max(len(ra), len(rb))
+	if l == 0 {
+		return 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(l)
+}
@@ -126,6 +126,8 @@ type Column struct {
 	PK              bool
 	FK              bool
 	HideForER       bool
+	// LintIgnore is the lint rules ignored for the column ( `tbls:lint-ignore` markers in the comment )
+	LintIgnore []string
 }
 
 type TableViewpoint struct {
@@ -148,6 +150,8 @@ type Table struct {
 	Labels           Labels
 	ReferencedTables []*Table
 	External         bool
+	// LintIgnore is the lint rules ignored for the table ( `tbls:lint-ignore` markers in the comment )
+	LintIgnore []string
 }
 
 // Relation is the struct for table relation.
@@ -576,6 +580,24 @@ func (t *Table) ShowColumn(name string, hideColumns []string) bool {
 	return true
 }
 
+// PrimaryKeyColumns return the column names of the primary key.
+// The primary key is found from the constraints, the indexes and Column.PK in that order.
+func (t *Table) PrimaryKeyColumns() []string {
+	for _, c := range t.Constraints {
+		if strings.EqualFold(c.Type, "PRIMARY KEY") && len(c.Columns) > 0 {
+			return c.Columns
+		}
+	}
+	for _, i := range t.Indexes {
+		if strings.Contains(strings.ToUpper(i.Def), "PRIMARY") && len(i.Columns) > 0 {
+			return i.Columns
+		}
+	}
+	return lo.FilterMap(t.Columns, func(c *Column, _ int) (string, bool) {
+		return c.Name, c.PK
+	})
+}
+
 func (t *Table) CollectTablesAndRelations(distance int, root bool) ([]*Table, []*Relation, error) {
 	tables := []*Table{}
 	relations := []*Relation{}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
 )
 
 func TestNormalizeTableName(t *testing.T) {
@@ -121,6 +122,38 @@ func TestTable_FindConstrainsByColumnName(t *testing.T) {
 	}
 }
 
+func TestTable_PrimaryKeyColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints []*Constraint
+		indexes     []*Index
+		pk          []string
+		want        []string
+	}{
+		{"constraint", []*Constraint{{Name: "a_pkey", Type: "PRIMARY KEY", Columns: []string{"a", "b"}}}, nil, nil, []string{"a", "b"}},
+		{"index", []*Constraint{{Name: "PRIMARY", Def: "PRIMARY KEY (a)"}}, []*Index{{Name: "PRIMARY KEY", Def: "PRIMARY KEY(a)", Columns: []string{"a"}}}, nil, []string{"a"}},
+		{"column", nil, nil, []string{"b"}, []string{"b"}},
+		{"none", []*Constraint{{Name: "b_unique", Type: "UNIQUE", Columns: []string{"b"}}}, nil, nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &Table{
+				Name: "testtable",
+				Columns: []*Column{
+					{Name: "a", PK: lo.Contains(tt.pk, "a")},
+					{Name: "b", PK: lo.Contains(tt.pk, "b")},
+				},
+				Constraints: tt.constraints,
+				Indexes:     tt.indexes,
+			}
+			got := table.PrimaryKeyColumns()
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestTable_hasColumnWithValues(t *testing.T) {
 	tests := []struct {
 		testName  string
@@ -22,6 +22,7 @@ func (t Table) MarshalYAML() ([]byte, error) {
 		Def              string        `yaml:"def,omitempty"`
 		Labels           Labels        `yaml:"labels,omitempty"`
 		ReferencedTables []string      `yaml:"referencedTables,omitempty"`
+		LintIgnore       []string      `yaml:"lintIgnore,omitempty"`
 	}{
 		Name:             t.Name,
 		Type:             t.Type,
@@ -33,6 +34,7 @@ func (t Table) MarshalYAML() ([]byte, error) {
 		Def:              t.Def,
 		Labels:           t.Labels,
 		ReferencedTables: referencedTables,
+		LintIgnore:       t.LintIgnore,
 	})
 }
 
@@ -47,6 +49,7 @@ func (c Column) MarshalYAML() ([]byte, error) {
 			ExtraDef        string      `yaml:"extraDef,omitempty"`
 			Labels          Labels      `yaml:"labels,omitempty"`
 			Comment         string      `yaml:"comment,omitempty"`
+			LintIgnore      []string    `yaml:"lintIgnore,omitempty"`
 			ParentRelations []*Relation `yaml:"-"`
 			ChildRelations  []*Relation `yaml:"-"`
 		}{
@@ -57,6 +60,7 @@ func (c Column) MarshalYAML() ([]byte, error) {
 			Comment:         c.Comment,
 			ExtraDef:        c.ExtraDef,
 			Labels:          c.Labels,
+			LintIgnore:      c.LintIgnore,
 			ParentRelations: c.ParentRelations,
 			ChildRelations:  c.ChildRelations,
 		})
@@ -69,6 +73,7 @@ func (c Column) MarshalYAML() ([]byte, error) {
 		ExtraDef        string      `yaml:"extraDef,omitempty"`
 		Labels          Labels      `yaml:"labels,omitempty"`
 		Comment         string      `yaml:"comment,omitempty"`
+		LintIgnore      []string    `yaml:"lintIgnore,omitempty"`
 		ParentRelations []*Relation `yaml:"-"`
 		ChildRelations  []*Relation `yaml:"-"`
 	}{
@@ -79,6 +84,7 @@ func (c Column) MarshalYAML() ([]byte, error) {
 		ExtraDef:        c.ExtraDef,
 		Labels:          c.Labels,
 		Comment:         c.Comment,
+		LintIgnore:      c.LintIgnore,
 		ParentRelations: c.ParentRelations,
 		ChildRelations:  c.ChildRelations,
 	})
@@ -129,6 +135,7 @@ func (t *Table) UnmarshalYAML(data []byte) error {
 		Def              string        `yaml:"def,omitempty"`
 		Labels           Labels        `yaml:"labels,omitempty"`
 		ReferencedTables []string      `yaml:"referencedTables,omitempty"`
+		LintIgnore       []string      `yaml:"lintIgnore,omitempty"`
 	}{}
 	err := yaml.Unmarshal(data, &s)
 	if err != nil {
@@ -143,6 +150,7 @@ func (t *Table) UnmarshalYAML(data []byte) error {
 	t.Triggers = s.Triggers
 	t.Def = s.Def
 	t.Labels = s.Labels
+	t.LintIgnore = s.LintIgnore
 	for _, rt := range s.ReferencedTables {
 		t.ReferencedTables = append(t.ReferencedTables, &Table{
 			Name: rt,
@@ -161,6 +169,7 @@ func (c *Column) UnmarshalYAML(data []byte) error {
 		Comment         string      `yaml:"comment,omitempty"`
 		ExtraDef        string      `yaml:"extraDef,omitempty"`
 		Labels          Labels      `yaml:"labels,omitempty"`
+		LintIgnore      []string    `yaml:"lintIgnore,omitempty"`
 		ParentRelations []*Relation `yaml:"-"`
 		ChildRelations  []*Relation `yaml:"-"`
 	}{}
@@ -181,6 +190,7 @@ func (c *Column) UnmarshalYAML(data []byte) error {
 	}
 	c.ExtraDef = s.ExtraDef
 	c.Labels = s.Labels
+	c.LintIgnore = s.LintIgnore
 	c.Comment = s.Comment
 	return nil
 }